@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"temporal-playground/internal/breaker"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	breakerOperator  string
+	breakerReason    string
+	breakerForceOpen bool
+)
+
+var breakerCmd = &cobra.Command{
+	Use:   "breaker",
+	Short: "Upstream circuit breaker commands",
+	Long:  `Commands to inspect the circuit breakers guarding the upstream providers, or force them closed or open.`,
+}
+
+var breakerStatusCmd = &cobra.Command{
+	Use:   "status [provider]",
+	Short: "Show the state of one or every circuit breaker",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+		breakers := breaker.NewClient(workflowManager, QueueQueryOrder)
+
+		var states []models.BreakerState
+		if len(args) > 0 {
+			state, err := breakers.State(context.Background(), args[0])
+			if err != nil {
+				log.Fatalf("Unable to get breaker state: %v", err)
+			}
+			states = append(states, state)
+		} else {
+			var err error
+			if states, err = breakers.List(context.Background()); err != nil {
+				log.Fatalf("Unable to list breakers: %v", err)
+			}
+		}
+
+		render(orEmpty(states), func() {
+			fmt.Printf("%-16s %-10s %-9s %-22s %s\n", "PROVIDER", "STATE", "FAILURES", "RETRY AT", "LAST ERROR")
+			for _, state := range states {
+				retryAt := "-"
+				if state.RetryAt != nil {
+					retryAt = state.RetryAt.Format(time.RFC3339)
+				} else if state.ForcedBy != "" {
+					retryAt = "forced by " + state.ForcedBy
+				}
+				fmt.Printf("%-16s %-10s %-9d %-22s %s\n", state.Provider, state.State, state.ConsecutiveFailures, retryAt, state.LastError)
+			}
+		})
+	},
+}
+
+var breakerResetCmd = &cobra.Command{
+	Use:   "reset [provider]",
+	Short: "Force a circuit breaker closed, or open with --open",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		reset := models.BreakerReset{
+			State:    models.BreakerClosed,
+			Operator: breakerOperator,
+			Reason:   breakerReason,
+		}
+		if breakerForceOpen {
+			reset.State = models.BreakerOpen
+		}
+
+		if err := breaker.NewClient(workflowManager, QueueQueryOrder).Reset(context.Background(), args[0], reset); err != nil {
+			log.Fatalf("Unable to reset breaker: %v", err)
+		}
+
+		render(actionResult{Action: "forced " + reset.State, Target: args[0], Detail: reset.Reason}, func() {
+			fmt.Printf("Forced the circuit breaker of %s %s\n", args[0], reset.State)
+		})
+	},
+}
+
+func init() {
+	clientCmd.AddCommand(breakerCmd)
+
+	breakerCmd.AddCommand(breakerStatusCmd)
+	breakerCmd.AddCommand(breakerResetCmd)
+
+	breakerResetCmd.Flags().BoolVar(&breakerForceOpen, "open", false, "Force the breaker open until it is reset again")
+	breakerResetCmd.Flags().StringVar(&breakerOperator, "operator", os.Getenv("USER"), "Operator forcing the breaker")
+	breakerResetCmd.Flags().StringVarP(&breakerReason, "reason", "m", "", "Why the breaker is forced")
+}
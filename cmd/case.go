@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	caseOperator string
+	caseAssignee string
+	caseComment  string
+	caseOverride bool
+)
+
+var caseCmd = &cobra.Command{
+	Use:   "case",
+	Short: "Manual case assignment commands",
+	Long:  `Commands to claim, reassign, comment on and resolve orders waiting in the manual queue.`,
+}
+
+var claimCaseCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Claim a manual case",
+	Run: func(cmd *cobra.Command, args []string) {
+		sendCaseAction(models.ManualCaseAction{Action: models.CaseActionClaim})
+	},
+}
+
+var unclaimCaseCmd = &cobra.Command{
+	Use:   "unclaim",
+	Short: "Release a claimed manual case",
+	Run: func(cmd *cobra.Command, args []string) {
+		sendCaseAction(models.ManualCaseAction{Action: models.CaseActionUnclaim})
+	},
+}
+
+var reassignCaseCmd = &cobra.Command{
+	Use:   "reassign",
+	Short: "Reassign a manual case to another operator",
+	Run: func(cmd *cobra.Command, args []string) {
+		sendCaseAction(models.ManualCaseAction{Action: models.CaseActionReassign, Assignee: caseAssignee})
+	},
+}
+
+var commentCaseCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Add a comment to a manual case",
+	Run: func(cmd *cobra.Command, args []string) {
+		sendCaseAction(models.ManualCaseAction{Action: models.CaseActionComment})
+	},
+}
+
+var resolveCaseCmd = &cobra.Command{
+	Use:   "resolve [resolution]",
+	Short: "Resolve a manual case as the operator",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		resolution := models.ResolutionManualResolve
+		if len(args) > 0 {
+			resolution = args[0]
+		}
+		sendCaseAction(models.ManualCaseAction{Action: models.CaseActionResolve, Resolution: resolution})
+	},
+}
+
+var historyCaseCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the assignee and audit trail of a manual case",
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		var state models.ManualCaseState
+		if err := workflowManager.QueryWorkflow(context.Background(), workflowID, "", workflows.QueryManualCaseState, &state); err != nil {
+			log.Fatalf("Unable to query case state: %v", err)
+		}
+
+		render(state, func() {
+			fmt.Printf("Order:    %s\n", state.OrderID)
+			fmt.Printf("Assignee: %s\n", state.Assignee)
+			fmt.Println("Audit trail:")
+			for _, entry := range state.AuditTrail {
+				status := ""
+				if entry.Rejected {
+					status = " (rejected)"
+				}
+				fmt.Printf("  %s  %-10s %-20s %s%s\n", entry.Time.Format(time.RFC3339), entry.Action, entry.Operator, entry.Detail, status)
+			}
+		})
+	},
+}
+
+// sendCaseAction signals a manual workflow with an action on behalf of the operator
+func sendCaseAction(action models.ManualCaseAction) {
+	workflowManager := temporal.NewWorkflowManager(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	})
+	defer workflowManager.Close()
+
+	action.Operator = caseOperator
+	action.Comment = caseComment
+	action.Override = caseOverride
+
+	if err := workflowManager.SignalWorkflow(context.Background(), workflowID, "", workflows.SignalManualCaseAction, action); err != nil {
+		log.Fatalf("Unable to signal workflow: %v", err)
+	}
+
+	render(actionResult{Action: action.Action, Target: workflowID, Detail: action.Operator}, func() {
+		fmt.Printf("Successfully sent '%s' by '%s' to manual workflow %s\n", action.Action, action.Operator, workflowID)
+	})
+}
+
+func init() {
+	clientCmd.AddCommand(caseCmd)
+
+	caseCmd.AddCommand(claimCaseCmd)
+	caseCmd.AddCommand(unclaimCaseCmd)
+	caseCmd.AddCommand(reassignCaseCmd)
+	caseCmd.AddCommand(commentCaseCmd)
+	caseCmd.AddCommand(resolveCaseCmd)
+	caseCmd.AddCommand(historyCaseCmd)
+
+	caseCmd.PersistentFlags().StringVarP(&workflowID, "workflow-id", "w", "", "Manual workflow ID")
+	caseCmd.MarkPersistentFlagRequired("workflow-id")
+	caseCmd.PersistentFlags().StringVar(&caseOperator, "operator", os.Getenv("USER"), "Operator identity recorded in the audit trail")
+	caseCmd.PersistentFlags().StringVarP(&caseComment, "comment", "m", "", "Comment or note for the action")
+
+	reassignCaseCmd.Flags().StringVar(&caseAssignee, "assignee", "", "Operator to assign the case to")
+	reassignCaseCmd.MarkFlagRequired("assignee")
+	for _, command := range []*cobra.Command{reassignCaseCmd, resolveCaseCmd} {
+		command.Flags().BoolVar(&caseOverride, "override", false, "Act on a case claimed by another operator")
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/repository"
 	"temporal-playground/internal/temporal"
 	"temporal-playground/internal/workflows"
 	"time"
@@ -29,6 +32,32 @@ var (
 	businessUnit          string
 	priority              string
 	recurringPaymentTerms int
+	useOrderLifecycle     bool
+)
+
+var (
+	contractAmount      string
+	contractCurrency    string
+	contractCustomerID  string
+	contractMetadata    map[string]string
+	contractDunning     string
+	scheduleInterval    time.Duration
+	scheduleCron        string
+	scheduleDaysOfMonth string
+	scheduleWeekdays    string
+	scheduleYearlyDates []string
+	scheduleTimeOfDay   string
+	scheduleTimeZone    string
+	scheduleStart       string
+	scheduleEnd         string
+	scheduleJitter      time.Duration
+	scheduleSkipDates   []string
+	scheduleHolidays    string
+	scheduleOverlap     string
+	scheduleCatchup     time.Duration
+	schedulePauseOnFail bool
+	schedulePreview     int
+	scheduleDryRun      bool
 )
 
 var clientCmd = &cobra.Command{
@@ -66,20 +95,44 @@ var startWorkflowCmd = &cobra.Command{
 			Priority:     priority,
 		}
 
-		_, err := workflowManager.StartWorkflow(
-			context.Background(),
-			workflowOptions,
-			workflows.QueryOrder,
-			orderIDFlag,
+		var (
+			run client.WorkflowRun
+			err error
 		)
+		if useOrderLifecycle {
+			// single long-lived workflow handling query, retries and manual resolution
+			run, err = workflowManager.StartWorkflow(
+				context.Background(),
+				workflowOptions,
+				workflows.OrderLifecycle,
+				models.OrderLifecycleRequest{OrderID: orderIDFlag},
+			)
+		} else {
+			run, err = workflowManager.StartWorkflow(
+				context.Background(),
+				workflowOptions,
+				workflows.QueryOrder,
+				orderIDFlag,
+			)
+		}
+
+		result := orders.SubmitOrderResult{OrderID: orderIDFlag, WorkflowID: workflowID}
 		if err != nil {
 			// Check if it's a duplicate workflow error
-			if strings.Contains(err.Error(), "WorkflowExecutionAlreadyStarted") {
-				log.Printf("Order %s is already being processed (workflow %s) - cannot start duplicate", orderIDFlag, workflowID)
-				return
+			if !temporal.IsAlreadyStarted(err) {
+				log.Fatalf("Unable to execute workflow: %v", err)
 			}
-			log.Fatalf("Unable to execute workflow: %v", err)
+			result.Duplicate = true
+			render(result, func() {
+				fmt.Printf("Order %s is already being processed (workflow %s) - cannot start duplicate\n", orderIDFlag, workflowID)
+			})
+			return
 		}
+
+		result.RunID = run.GetRunID()
+		render(result, func() {
+			fmt.Printf("Started workflow %s (run %s) for order %s\n", workflowID, result.RunID, orderIDFlag)
+		})
 	},
 }
 
@@ -134,7 +187,7 @@ var simulatePaymentWorkflowCmd = &cobra.Command{
 					)
 					if err != nil {
 						// Check if it's a duplicate workflow error
-						if strings.Contains(err.Error(), "WorkflowExecutionAlreadyStarted") {
+						if temporal.IsAlreadyStarted(err) {
 							log.Printf("Order %s is already being processed (workflow %s) - skipping", orderID, workflowID)
 							continue
 						}
@@ -178,20 +231,97 @@ var signalManualWorkflowCmd = &cobra.Command{
 			context.Background(),
 			workflowID,
 			"",
-			"resolve-manual-order",
+			workflows.SignalResolveManualOrder,
 			resolution,
 		)
 		if err != nil {
 			log.Fatalf("Unable to signal workflow: %v", err)
 		}
 
-		log.Printf("Successfully sent signal '%s' to manual workflow %s", resolution, workflowID)
+		render(actionResult{Action: "signalled", Target: workflowID, Detail: resolution}, func() {
+			fmt.Printf("Successfully sent signal '%s' to manual workflow %s\n", resolution, workflowID)
+		})
+	},
+}
+
+var slaStatusCmd = &cobra.Command{
+	Use:   "sla-status",
+	Short: "Show the SLA state of a manual workflow",
+	Long:  `Query a running manual workflow for its SLA deadlines, status and the alerts sent so far.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		var state models.SLAState
+		if err := workflowManager.QueryWorkflow(context.Background(), workflowID, "", workflows.QueryManualSLAState, &state); err != nil {
+			log.Fatalf("Unable to query SLA state: %v", err)
+		}
+
+		render(state, func() {
+			fmt.Printf("Order:           %s\n", state.OrderID)
+			fmt.Printf("Business unit:   %s\n", state.BusinessUnit)
+			fmt.Printf("Priority:        %s\n", state.Priority)
+			fmt.Printf("Status:          %s\n", state.Status)
+			fmt.Printf("Entered at:      %s\n", state.EnteredAt.Format(time.RFC3339))
+			if state.WarningAt != nil {
+				fmt.Printf("Warning at:      %s\n", state.WarningAt.Format(time.RFC3339))
+			}
+			if state.BreachAt != nil {
+				fmt.Printf("Breach at:       %s\n", state.BreachAt.Format(time.RFC3339))
+			}
+			if state.AutoResolveAt != nil {
+				fmt.Printf("Auto-resolve at: %s\n", state.AutoResolveAt.Format(time.RFC3339))
+			}
+			fmt.Printf("Alerts sent:     %s\n", strings.Join(state.AlertsSent, ", "))
+		})
+	},
+}
+
+var notificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Show the webhook delivery log",
+	Long:  `Show every webhook delivery attempt recorded by the workers in the order database, optionally for a single order.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		deliveryRepository, err := repository.Open(databaseDSN)
+		if err != nil {
+			log.Fatalf("Unable to open order database: %v", err)
+		}
+		defer deliveryRepository.Close()
+
+		deliveries, err := deliveryRepository.ListDeliveries(context.Background(), orderID)
+		if err != nil {
+			log.Fatalf("Unable to read webhook delivery log: %v", err)
+		}
+
+		render(orEmpty(deliveries), func() {
+			for _, delivery := range deliveries {
+				outcome := fmt.Sprintf("%d", delivery.StatusCode)
+				if delivery.Error != "" {
+					outcome = "failed: " + delivery.Error
+				}
+				fmt.Printf("%s  %-20s %-16s attempt %d  %s  %s\n",
+					delivery.DeliveredAt.Format(time.RFC3339), delivery.OrderID, delivery.Resolution, delivery.Attempt, delivery.Endpoint, outcome)
+			}
+			fmt.Printf("\nTotal: %d deliveries\n", len(deliveries))
+		})
 	},
 }
 
 var createRecurringPaymentCmd = &cobra.Command{
 	Use:   "create-recurring-payment",
 	Short: "Create a recurring payment for a customer",
+	Long: `Create a recurring payment schedule for a consent. Payments are taken on one of:
+  --interval      a fixed interval, e.g. 720h
+  --cron          a standard cron expression, e.g. "0 9 1 * *"
+  --day-of-month  days of the month, e.g. 1,15 or last; days past the end of a month fall on its last day
+  --weekday       days of the week, e.g. mon,thu
+  --yearly        dates of the year, e.g. 01-31
+The next run times are previewed before the schedule is created; --dry-run only previews them.
+A payment due while the last one still runs waits for it (--overlap buffer-one), and payments missed
+for up to --catchup-window, e.g. during an outage, are still taken.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		orderIDFlag, _ := cmd.Flags().GetString("order-id")
@@ -199,39 +329,125 @@ var createRecurringPaymentCmd = &cobra.Command{
 			orderIDFlag = uuid.NewString()
 		}
 
-		workflowManager := temporal.NewWorkflowManager(client.Options{
-			HostPort:  hostPort,
-			Namespace: namespace,
-		})
+		request := orders.CreateRecurringPaymentRequest{
+			ConsentID:      orderIDFlag,
+			CustomerID:     contractCustomerID,
+			Metadata:       contractMetadata,
+			Terms:          recurringPaymentTerms,
+			Interval:       scheduleInterval,
+			Cron:           scheduleCron,
+			YearlyDates:    scheduleYearlyDates,
+			TimeOfDay:      scheduleTimeOfDay,
+			TimeZone:       scheduleTimeZone,
+			Jitter:         scheduleJitter,
+			SkipDates:      scheduleSkipDates,
+			CatchupWindow:  scheduleCatchup,
+			PauseOnFailure: schedulePauseOnFail,
+			Environment:    environment,
+			BusinessUnit:   businessUnit,
+			Priority:       priority,
+		}
+
+		var err error
+		if request.Amount, err = models.ParseMoney(contractAmount, contractCurrency); err != nil {
+			log.Fatalf("Invalid --amount: %v", err)
+		}
+		if request.Dunning, err = orders.ParseDunningSchedule(contractDunning); err != nil {
+			log.Fatalf("Invalid --dunning: %v", err)
+		}
+		if request.Overlap, err = orders.ParseOverlapPolicy(scheduleOverlap); err != nil {
+			log.Fatalf("Invalid --overlap: %v", err)
+		}
+		if request.DaysOfMonth, err = orders.ParseDaysOfMonth(scheduleDaysOfMonth); err != nil {
+			log.Fatalf("Invalid --day-of-month: %v", err)
+		}
+		if request.Weekdays, err = orders.ParseWeekdays(scheduleWeekdays); err != nil {
+			log.Fatalf("Invalid --weekday: %v", err)
+		}
+		if request.Start, err = orders.ParseScheduleDate(scheduleStart, scheduleTimeZone); err != nil {
+			log.Fatalf("Invalid --start: %v", err)
+		}
+		if request.End, err = orders.ParseScheduleEnd(scheduleEnd, scheduleTimeZone); err != nil {
+			log.Fatalf("Invalid --end: %v", err)
+		}
+		if scheduleHolidays != "" {
+			holidays, err := readHolidayCalendar(scheduleHolidays)
+			if err != nil {
+				log.Fatalf("Unable to read holiday calendar: %v", err)
+			}
+			request.SkipDates = append(request.SkipDates, holidays...)
+		}
+
+		times, err := orders.PreviewRecurringPayment(request, time.Now(), schedulePreview)
+		if err != nil {
+			log.Fatalf("Invalid recurring payment schedule: %v", err)
+		}
+		result := scheduledPayment{
+			ConsentID:        orderIDFlag,
+			Amount:           request.Amount,
+			TimeZone:         scheduleTimeZone,
+			Jitter:           scheduleJitter,
+			NextPaymentTimes: orEmpty(times),
+		}
+		printPreview := func() {
+			fmt.Printf("Next %d payments of %s (%s):\n", len(times), request.Amount, scheduleTimeZone)
+			for _, next := range times {
+				fmt.Printf("  %s\n", next.Format("Mon 2006-01-02 15:04:05 MST"))
+			}
+			if scheduleJitter > 0 {
+				fmt.Printf("Each payment is delayed by up to %s of jitter\n", scheduleJitter)
+			}
+		}
+		if scheduleDryRun {
+			render(result, printPreview)
+			return
+		}
+
+		service, workflowManager := newOrderService()
 		defer workflowManager.Close()
 
-		scheduleHandle, err := workflowManager.StartScheduledWorkflow(context.Background(), temporal.ScheduleWorkflowOptions{
-			RemainingActions: recurringPaymentTerms,
-			Specs: client.ScheduleSpec{
-				TimeZoneName: "Asia/Kuala_Lumpur",
-				Intervals: []client.ScheduleIntervalSpec{
-					{
-						Every: 1 * time.Minute,
-					},
-				},
-			},
-			StartWorkflowOptions: temporal.StartWorkflowOptions{
-				WorkflowID:   orderIDFlag,
-				TaskQueue:    QueueRecurringSchedule,
-				OrderID:      orderIDFlag,
-				Environment:  environment,
-				BusinessUnit: businessUnit,
-				Priority:     priority,
-			},
-		}, workflows.RegisterRecurringPayment, orderIDFlag)
+		payment, err := service.CreateRecurringPayment(context.Background(), request)
 		if err != nil {
 			log.Fatalf("Failed to schedule recurring payment workflow: %v", err)
 		}
 
-		log.Printf("Successfully scheduled recurring payment workflow: %s (schedule ID: %s)", orderIDFlag, scheduleHandle.GetID())
+		result.Created = true
+		render(result, func() {
+			printPreview()
+			fmt.Printf("Successfully scheduled recurring payment workflow: %s (schedule ID: %s)\n", orderIDFlag, payment.ConsentID)
+		})
 	},
 }
 
+// scheduledPayment is the output of create-recurring-payment
+type scheduledPayment struct {
+	ConsentID        string        `json:"consentID"`
+	Amount           models.Money  `json:"amount"`
+	TimeZone         string        `json:"timeZone"`
+	Jitter           time.Duration `json:"jitter,omitempty"` // nanoseconds each payment is delayed by at most
+	NextPaymentTimes []time.Time   `json:"nextPaymentTimes"`
+	Created          bool          `json:"created"` // false on --dry-run
+}
+
+// readHolidayCalendar reads the YYYY-MM-DD dates of a holiday calendar file, one per line; text after
+// the date and lines starting with # are ignored
+func readHolidayCalendar(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		dates = append(dates, fields[0])
+	}
+	return dates, nil
+}
+
 var cancelRecurringPaymentCmd = &cobra.Command{
 	Use:   "cancel-recurring-payment",
 	Short: "Cancel a recurring payment workflow",
@@ -258,7 +474,9 @@ var cancelRecurringPaymentCmd = &cobra.Command{
 			log.Fatalf("Unable to cancel workflow: %v", err)
 		}
 
-		log.Printf("Successfully cancelled recurring payment workflow %s", workflowID)
+		render(actionResult{Action: "cancelled", Target: orderID}, func() {
+			fmt.Printf("Successfully cancelled recurring payment workflow %s\n", orderID)
+		})
 	},
 }
 
@@ -268,6 +486,8 @@ func init() {
 	clientCmd.AddCommand(startWorkflowCmd)
 	clientCmd.AddCommand(simulatePaymentWorkflowCmd)
 	clientCmd.AddCommand(signalManualWorkflowCmd)
+	clientCmd.AddCommand(slaStatusCmd)
+	clientCmd.AddCommand(notificationsCmd)
 	clientCmd.AddCommand(createRecurringPaymentCmd)
 	clientCmd.AddCommand(cancelRecurringPaymentCmd)
 
@@ -277,6 +497,7 @@ func init() {
 	startWorkflowCmd.Flags().StringVarP(&environment, "environment", "e", "development", "Environment (dev/staging/prod)")
 	startWorkflowCmd.Flags().StringVarP(&businessUnit, "business-unit", "b", "retail", "Business unit")
 	startWorkflowCmd.Flags().StringVarP(&priority, "priority", "p", "normal", "Priority level (low/normal/high/urgent)")
+	startWorkflowCmd.Flags().BoolVar(&useOrderLifecycle, "lifecycle", false, "Use the single OrderLifecycle workflow instead of chained QueryOrder/Stale/ManualHandle workflows")
 
 	// Flags for start-workflow command
 	simulatePaymentWorkflowCmd.Flags().StringVarP(&environment, "environment", "e", "development", "Environment (dev/staging/prod)")
@@ -287,11 +508,40 @@ func init() {
 	signalManualWorkflowCmd.Flags().StringVarP(&workflowID, "workflow-id", "w", "", "Manual workflow ID to signal")
 	signalManualWorkflowCmd.MarkFlagRequired("workflow-id")
 
+	slaStatusCmd.Flags().StringVarP(&workflowID, "workflow-id", "w", "", "Manual workflow ID to query")
+	slaStatusCmd.MarkFlagRequired("workflow-id")
+
+	notificationsCmd.Flags().StringVarP(&orderID, "order-id", "o", "", "Only show deliveries for this order")
+	notificationsCmd.Flags().StringVar(&databaseDSN, "database", "orders.db", "Order database: a SQLite file path or a postgres:// URL")
+
 	createRecurringPaymentCmd.Flags().IntVarP(&recurringPaymentTerms, "terms", "r", 0, "Number of payment terms (0 means infinite)")
 	createRecurringPaymentCmd.Flags().StringVarP(&orderID, "order-id", "o", "", "Use this as consent ID")
 	createRecurringPaymentCmd.Flags().StringVarP(&environment, "environment", "e", "development", "Environment (dev/staging/prod)")
 	createRecurringPaymentCmd.Flags().StringVarP(&businessUnit, "business-unit", "b", "retail", "Business unit")
 	createRecurringPaymentCmd.Flags().StringVarP(&priority, "priority", "p", "normal", "Priority level (low/normal/high/urgent)")
+	createRecurringPaymentCmd.Flags().StringVar(&contractAmount, "amount", "", "Amount of each payment in the major unit of the currency, e.g. 15.50")
+	createRecurringPaymentCmd.Flags().StringVar(&contractCurrency, "currency", "MYR", "ISO 4217 currency of the amount")
+	createRecurringPaymentCmd.Flags().StringVar(&contractCustomerID, "customer", "", "Customer the consent belongs to")
+	createRecurringPaymentCmd.Flags().StringToStringVar(&contractMetadata, "metadata", nil, "Contract metadata, e.g. plan=gold,branch=kl")
+	createRecurringPaymentCmd.Flags().StringVar(&contractDunning, "dunning", "", "Retry a failed payment this long after it failed, e.g. 1d,3d,7d (the default)")
+	createRecurringPaymentCmd.MarkFlagRequired("amount")
+	createRecurringPaymentCmd.Flags().DurationVar(&scheduleInterval, "interval", 0, "Take a payment every interval, e.g. 720h")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleCron, "cron", "", "Take payments on a cron expression, e.g. \"0 9 1 * *\"")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleDaysOfMonth, "day-of-month", "", "Take payments on these days of the month, e.g. 1,15 or last")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleWeekdays, "weekday", "", "Take payments weekly on these days, e.g. mon,thu")
+	createRecurringPaymentCmd.Flags().StringSliceVar(&scheduleYearlyDates, "yearly", nil, "Take payments yearly on these dates (MM-DD)")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleTimeOfDay, "at", "", "Time of day (HH:MM) of --day-of-month, --weekday and --yearly payments, defaults to 00:00")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleTimeZone, "timezone", "Asia/Kuala_Lumpur", "Time zone of the schedule")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleStart, "start", "", "No payments before this date (YYYY-MM-DD or RFC3339)")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleEnd, "end", "", "No payments after this date (YYYY-MM-DD or RFC3339)")
+	createRecurringPaymentCmd.Flags().DurationVar(&scheduleJitter, "jitter", 0, "Delay each payment by a random duration up to this")
+	createRecurringPaymentCmd.Flags().StringSliceVar(&scheduleSkipDates, "skip", nil, "Skip payments on these dates (YYYY-MM-DD)")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleHolidays, "holidays", "", "File of YYYY-MM-DD public holidays to skip, one per line")
+	createRecurringPaymentCmd.Flags().StringVar(&scheduleOverlap, "overlap", "buffer-one", "What to do with a payment due while the last one runs: skip, buffer-one, buffer-all, cancel-other, terminate-other or allow-all")
+	createRecurringPaymentCmd.Flags().DurationVar(&scheduleCatchup, "catchup-window", orders.DefaultPaymentCatchupWindow, "Take payments missed for up to this long, e.g. while the server was down")
+	createRecurringPaymentCmd.Flags().BoolVar(&schedulePauseOnFail, "pause-on-failure", false, "Pause the schedule when a payment fails instead of leaving it to dunning")
+	createRecurringPaymentCmd.Flags().IntVar(&schedulePreview, "preview", 5, "Number of next payment times to preview")
+	createRecurringPaymentCmd.Flags().BoolVar(&scheduleDryRun, "dry-run", false, "Only preview the schedule, do not create it")
 
 	cancelRecurringPaymentCmd.Flags().StringVarP(&orderID, "order-id", "o", "", "Order ID to cancel")
 	cancelRecurringPaymentCmd.MarkFlagRequired("order-id")
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"log"
+	"net/http"
+	"temporal-playground/internal/console"
+	"temporal-playground/internal/temporal"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var consoleListenAddr string
+
+var consoleCmd = &cobra.Command{
+	Use:   "console",
+	Short: "Serve the operator web console for the manual-handle queue",
+	Long:  `Serve a small web UI and JSON API to list open manual workflows, inspect their failures and resolve or reassign them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		server := console.NewServer(workflowManager)
+
+		log.Printf("Operator console listening on http://%s", consoleListenAddr)
+		if err := http.ListenAndServe(consoleListenAddr, server.Handler()); err != nil {
+			log.Fatalf("Console server stopped: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(consoleCmd)
+
+	consoleCmd.Flags().StringVar(&consoleListenAddr, "listen", "localhost:8080", "Address for the console to listen on")
+}
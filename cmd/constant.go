@@ -6,3 +6,6 @@ const (
 	QueueManualHandle      = "manual-handle"
 	QueueRecurringSchedule = "recurring-schedule"
 )
+
+// DefaultDeploymentName is the worker deployment that versioned workers of this application join
+const DefaultDeploymentName = "temporal-playground"
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"temporal-playground/internal/events"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/temporal"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	consumeSource      string
+	consumeFile        string
+	kafkaBrokers       string
+	kafkaTopic         string
+	kafkaGroupID       string
+	deadLetterFile     string
+	deadLetterTopic    string
+	consumeMaxInFlight int
+)
+
+var consumeCmd = &cobra.Command{
+	Use:   "consume",
+	Short: "Start QueryOrder workflows from payment pending events",
+	Long:  `Consume payment pending events from Kafka or a JSONL file/stdin and start a QueryOrder workflow for each order, committing offsets only once the workflow start succeeded. Malformed events go to a dead-letter sink.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			source      events.Source
+			deadLetters events.DeadLetterSink
+			err         error
+		)
+
+		switch consumeSource {
+		case "kafka":
+			source = events.NewKafkaSource(strings.Split(kafkaBrokers, ","), kafkaTopic, kafkaGroupID)
+		case "file":
+			if source, err = events.NewFileSource(consumeFile); err != nil {
+				log.Fatalf("Unable to open event source: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown source '%s'. Use kafka or file.", consumeSource)
+		}
+		defer source.Close()
+
+		if deadLetterTopic != "" {
+			deadLetters = events.NewKafkaDeadLetterSink(strings.Split(kafkaBrokers, ","), deadLetterTopic)
+		} else if deadLetters, err = events.NewFileDeadLetterSink(deadLetterFile); err != nil {
+			log.Fatalf("Unable to open dead-letter sink: %v", err)
+		}
+		defer deadLetters.Close()
+
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		consumer := events.NewConsumer(source, deadLetters, orders.NewService(workflowManager, orderServiceConfig()), events.ConsumerOptions{
+			MaxInFlight:  consumeMaxInFlight,
+			Environment:  environment,
+			BusinessUnit: businessUnit,
+			Priority:     priority,
+		})
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		log.Printf("Consuming payment pending events from %s source", consumeSource)
+		if err := consumer.Run(ctx); err != nil {
+			log.Fatalf("Consumer stopped: %v", err)
+		}
+		log.Println("Consumer stopped")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(consumeCmd)
+
+	consumeCmd.Flags().StringVar(&consumeSource, "source", "file", "Event source (kafka/file)")
+	consumeCmd.Flags().StringVarP(&consumeFile, "file", "f", "-", "JSONL file to read events from, - for stdin (file source)")
+	consumeCmd.Flags().StringVar(&kafkaBrokers, "brokers", "localhost:9092", "Comma separated Kafka brokers (kafka source)")
+	consumeCmd.Flags().StringVar(&kafkaTopic, "topic", "payment-events", "Kafka topic to consume (kafka source)")
+	consumeCmd.Flags().StringVar(&kafkaGroupID, "group-id", "temporal-playground", "Kafka consumer group (kafka source)")
+	consumeCmd.Flags().StringVar(&deadLetterFile, "dead-letter-file", "-", "JSONL file for malformed events, - for stderr")
+	consumeCmd.Flags().StringVar(&deadLetterTopic, "dead-letter-topic", "", "Kafka topic for malformed events, overrides --dead-letter-file")
+	consumeCmd.Flags().IntVar(&consumeMaxInFlight, "max-in-flight", 100, "Maximum events being processed before fetching pauses")
+	consumeCmd.Flags().StringVarP(&environment, "environment", "e", "development", "Default environment when the event has none")
+	consumeCmd.Flags().StringVarP(&businessUnit, "business-unit", "b", "retail", "Default business unit when the event has none")
+	consumeCmd.Flags().StringVarP(&priority, "priority", "p", "normal", "Default priority when the event has none")
+}
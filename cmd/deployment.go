@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	deploymentBuildID       string
+	deploymentPercentage    float32
+	deploymentIgnoreMissing bool
+)
+
+var deploymentCmd = &cobra.Command{
+	Use:   "deployment",
+	Short: "Worker deployment commands",
+	Long: `Commands to roll out versioned worker builds, started with worker --build-id.
+New workflows go to the deployment's current build, or to its ramping build for a percentage of them;
+pinned workflows complete on the build that started them and auto-upgrade workflows move to the current build.`,
+}
+
+var listDeploymentsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the worker deployments and their current and ramping builds",
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentManager := newDeploymentManager()
+		defer deploymentManager.Close()
+
+		deployments, err := deploymentManager.ListDeployments(context.Background())
+		if err != nil {
+			log.Fatalf("Unable to list worker deployments: %v", err)
+		}
+
+		render(orEmpty(deployments), func() {
+			fmt.Printf("%-24s %-20s %-20s %s\n", "DEPLOYMENT", "CURRENT", "RAMPING", "CREATED AT")
+			for _, deployment := range deployments {
+				fmt.Printf("%-24s %-20s %-20s %s\n", deployment.Name, currentBuild(deployment), rampingBuild(deployment),
+					deployment.CreatedAt.Format(time.RFC3339))
+			}
+			fmt.Printf("\nTotal: %d deployments\n", len(deployments))
+		})
+	},
+}
+
+var describeDeploymentCmd = &cobra.Command{
+	Use:   "describe [deployment]",
+	Short: "Show which build serves each task queue of a worker deployment",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentManager := newDeploymentManager()
+		defer deploymentManager.Close()
+
+		deployment, err := deploymentManager.DescribeDeployment(context.Background(), deploymentArg(args))
+		if err != nil {
+			log.Fatalf("Unable to describe worker deployment: %v", err)
+		}
+
+		render(deployment, func() {
+			fmt.Printf("Deployment: %s\n", deployment.Name)
+			fmt.Printf("Current:    %s\n", currentBuild(deployment))
+			fmt.Printf("Ramping:    %s\n", rampingBuild(deployment))
+
+			fmt.Printf("\n%-20s %-20s %-14s %s\n", "TASK QUEUE", "BUILD", "ROUTING", "CREATED AT")
+			for _, version := range deployment.Versions {
+				routing := version.DrainageStatus
+				switch {
+				case version.Current:
+					routing = "current"
+				case version.BuildID == deployment.RampingBuildID:
+					routing = fmt.Sprintf("ramping %g%%", version.RampPercentage)
+				case routing == "":
+					routing = "inactive"
+				}
+				for _, taskQueue := range version.TaskQueues {
+					fmt.Printf("%-20s %-20s %-14s %s\n", taskQueue, version.BuildID, routing, version.CreatedAt.Format(time.RFC3339))
+				}
+			}
+		})
+	},
+}
+
+var setCurrentDeploymentCmd = &cobra.Command{
+	Use:   "set-current [deployment]",
+	Short: "Route new workflows and auto-upgrade workflows to a build",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentManager := newDeploymentManager()
+		defer deploymentManager.Close()
+
+		if err := deploymentManager.SetCurrentVersion(context.Background(), deploymentArg(args), deploymentBuildID, deploymentIgnoreMissing); err != nil {
+			log.Fatalf("Unable to set the current build: %v", err)
+		}
+		render(actionResult{Action: "set current", Target: deploymentArg(args), Detail: deploymentBuildID}, func() {
+			fmt.Printf("Build %s is now the current build of %s\n", deploymentBuildID, deploymentArg(args))
+		})
+	},
+}
+
+var setRampingDeploymentCmd = &cobra.Command{
+	Use:   "set-ramping [deployment]",
+	Short: "Route a percentage of the new workflows to a build, e.g. a canary; --percentage 0 pauses the ramp",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentManager := newDeploymentManager()
+		defer deploymentManager.Close()
+
+		if err := deploymentManager.SetRampingVersion(context.Background(), deploymentArg(args), deploymentBuildID, deploymentPercentage); err != nil {
+			log.Fatalf("Unable to set the ramping build: %v", err)
+		}
+		render(actionResult{Action: "set ramping", Target: deploymentArg(args), Detail: fmt.Sprintf("%s %g%%", deploymentBuildID, deploymentPercentage)}, func() {
+			fmt.Printf("Build %s now receives %g%% of the new workflows of %s\n", deploymentBuildID, deploymentPercentage, deploymentArg(args))
+		})
+	},
+}
+
+func newDeploymentManager() *temporal.DeploymentManager {
+	return temporal.NewDeploymentManager(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	})
+}
+
+// deploymentArg returns the deployment named on the command line, defaulting to the one workers join
+func deploymentArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return DefaultDeploymentName
+}
+
+func currentBuild(deployment temporal.Deployment) string {
+	if deployment.CurrentBuildID == "" {
+		return "unversioned"
+	}
+	return deployment.CurrentBuildID
+}
+
+func rampingBuild(deployment temporal.Deployment) string {
+	if deployment.RampingBuildID == "" && deployment.RampingPercentage == 0 {
+		return "-"
+	}
+	build := deployment.RampingBuildID
+	if build == "" {
+		build = "unversioned"
+	}
+	return fmt.Sprintf("%s %g%%", build, deployment.RampingPercentage)
+}
+
+func init() {
+	rootCmd.AddCommand(deploymentCmd)
+
+	deploymentCmd.AddCommand(listDeploymentsCmd)
+	deploymentCmd.AddCommand(describeDeploymentCmd)
+	deploymentCmd.AddCommand(setCurrentDeploymentCmd)
+	deploymentCmd.AddCommand(setRampingDeploymentCmd)
+
+	setCurrentDeploymentCmd.Flags().StringVar(&deploymentBuildID, "build-id", "", "Build to make current; empty routes to unversioned workers")
+	setCurrentDeploymentCmd.Flags().BoolVar(&deploymentIgnoreMissing, "ignore-missing-task-queues", false, "Allow a build that does not poll every task queue of the current build")
+	setCurrentDeploymentCmd.MarkFlagRequired("build-id")
+
+	setRampingDeploymentCmd.Flags().StringVar(&deploymentBuildID, "build-id", "", "Build to ramp; empty ramps to unversioned workers")
+	setRampingDeploymentCmd.Flags().Float32Var(&deploymentPercentage, "percentage", 0, "Percentage of the new workflows routed to the build (0-100)")
+	setRampingDeploymentCmd.MarkFlagRequired("percentage")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"log"
+	"net"
+	"temporal-playground/internal/orchestrator"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/temporal"
+	orchestratorv1 "temporal-playground/proto/orchestrator/v1"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var grpcListenAddr string
+
+var grpcServerCmd = &cobra.Command{
+	Use:   "grpc-server",
+	Short: "Serve the OrderOrchestrator gRPC service",
+	Long:  `Serve the OrderOrchestrator gRPC service so microservices can submit, inspect and resolve orders without importing the Temporal SDK.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		listener, err := net.Listen("tcp", grpcListenAddr)
+		if err != nil {
+			log.Fatalf("Unable to listen on %s: %v", grpcListenAddr, err)
+		}
+
+		server := grpc.NewServer()
+		orchestratorv1.RegisterOrderOrchestratorServer(server, orchestrator.NewServer(orders.NewService(workflowManager, orderServiceConfig())))
+		reflection.Register(server)
+
+		log.Printf("OrderOrchestrator gRPC service listening on %s", grpcListenAddr)
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("gRPC server stopped: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grpcServerCmd)
+
+	grpcServerCmd.Flags().StringVar(&grpcListenAddr, "listen", "localhost:9090", "Address for the gRPC service to listen on")
+}
@@ -1,19 +1,32 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"log"
+	"maps"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"temporal-playground/internal/temporal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.temporal.io/sdk/client"
 )
 
 var (
-	namespaceName string
-	namespaceDesc string
-	retentionDays string
+	namespaceName      string
+	namespaceDesc      string
+	retentionDays      string
+	namespaceOwner     string
+	namespaceRetention string
+	namespaceData      map[string]string
+	namespaceConfirm   bool
+	namespacePrefix    string
+	namespaceState     string
 )
 
 var namespaceCmd = &cobra.Command{
@@ -44,15 +57,18 @@ var registerNamespaceCmd = &cobra.Command{
 			HostPort:  hostPort,
 			Namespace: namespace,
 		})
-		err = namespaceManager.RegisterNamespace(namespaceName, namespaceDesc, retention)
+		defer namespaceManager.Close()
+		err = namespaceManager.RegisterNamespace(context.Background(), namespaceName, namespaceDesc, retention)
 		if err != nil {
 			log.Fatalf("Failed to register namespace: %v", err)
 		}
 
-		fmt.Printf("✅ Namespace '%s' registered successfully!\n", namespaceName)
-		fmt.Println("You can now use this namespace with:")
-		fmt.Printf("  ./temporal-playground worker --namespace %s\n", namespaceName)
-		fmt.Printf("  ./temporal-playground client start --namespace %s\n", namespaceName)
+		render(actionResult{Action: "registered", Target: namespaceName}, func() {
+			fmt.Printf("✅ Namespace '%s' registered successfully!\n", namespaceName)
+			fmt.Println("You can now use this namespace with:")
+			fmt.Printf("  ./temporal-playground worker --namespace %s\n", namespaceName)
+			fmt.Printf("  ./temporal-playground client start --namespace %s\n", namespaceName)
+		})
 	},
 }
 
@@ -63,32 +79,187 @@ var listNamespacesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Println("Fetching namespaces...")
 
-		namespaceManager := temporal.NewNamespaceManager(client.Options{
-			HostPort:  hostPort,
-			Namespace: namespace,
+		namespaceManager := newNamespaceManager()
+		defer namespaceManager.Close()
+		namespaces, err := namespaceManager.ListNamespaces(context.Background(), temporal.NamespaceFilter{
+			Prefix: namespacePrefix,
+			State:  namespaceState,
 		})
-		namespaces, err := namespaceManager.ListNamespaces()
 		if err != nil {
 			log.Fatalf("Failed to list namespaces: %v", err)
 		}
 
-		fmt.Println("\n📋 Available Namespaces:")
-		fmt.Println("========================")
-		for i, ns := range namespaces {
-			fmt.Printf("%d. %s\n", i+1, ns)
+		render(orEmpty(namespaces), func() {
+			fmt.Println("\n📋 Available Namespaces:")
+			fmt.Println("========================")
+			fmt.Printf("%-32s %-12s %-10s %s\n", "NAME", "STATE", "RETENTION", "OWNER")
+			for _, ns := range namespaces {
+				fmt.Printf("%-32s %-12s %-10s %s\n", ns.Name, ns.State, fmt.Sprintf("%dd", int(ns.Retention.Hours()/24)), ns.OwnerEmail)
+			}
+			fmt.Printf("\nTotal: %d namespaces\n", len(namespaces))
+		})
+	},
+}
+
+var describeNamespaceCmd = &cobra.Command{
+	Use:   "describe [namespace]",
+	Short: "Show the retention, state, owner, custom data, archival and search attributes of a namespace",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespaceManager := newNamespaceManager()
+		defer namespaceManager.Close()
+
+		description, err := namespaceManager.DescribeNamespace(context.Background(), args[0])
+		if err != nil {
+			log.Fatalf("Failed to describe namespace: %v", err)
+		}
+		render(description, func() { printNamespace(description) })
+	},
+}
+
+var updateNamespaceCmd = &cobra.Command{
+	Use:   "update [namespace]",
+	Short: "Change the description, owner, retention or custom data of a namespace",
+	Long:  `Change the description, owner, retention or custom data of a namespace. --data is merged into the existing custom data.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var update temporal.NamespaceUpdate
+		if cmd.Flags().Changed("description") {
+			update.Description = &namespaceDesc
+		}
+		if cmd.Flags().Changed("owner-email") {
+			update.OwnerEmail = &namespaceOwner
+		}
+		if cmd.Flags().Changed("retention") {
+			days, err := strconv.Atoi(namespaceRetention)
+			if err != nil {
+				log.Fatalf("Invalid retention days: %s. Must be a number.", namespaceRetention)
+			}
+			retention := time.Duration(days) * 24 * time.Hour
+			update.Retention = &retention
+		}
+		update.Data = namespaceData
+		if update.Description == nil && update.OwnerEmail == nil && update.Retention == nil && len(update.Data) == 0 {
+			log.Fatal("Nothing to update. Use --description, --owner-email, --retention or --data.")
+		}
+
+		namespaceManager := newNamespaceManager()
+		defer namespaceManager.Close()
+
+		description, err := namespaceManager.UpdateNamespace(context.Background(), args[0], update)
+		if err != nil {
+			log.Fatalf("Failed to update namespace: %v", err)
+		}
+		render(description, func() { printNamespace(description) })
+	},
+}
+
+var deprecateNamespaceCmd = &cobra.Command{
+	Use:   "deprecate [namespace]",
+	Short: "Deprecate a namespace: no new workflows start in it, running workflows go on",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmNamespace(args[0], "deprecate")
+
+		namespaceManager := newNamespaceManager()
+		defer namespaceManager.Close()
+
+		if err := namespaceManager.DeprecateNamespace(context.Background(), args[0]); err != nil {
+			log.Fatalf("Failed to deprecate namespace: %v", err)
 		}
-		fmt.Printf("\nTotal: %d namespaces\n", len(namespaces))
+		render(actionResult{Action: "deprecated", Target: args[0]}, func() {
+			fmt.Printf("✅ Namespace '%s' deprecated\n", args[0])
+		})
 	},
 }
 
+var deleteNamespaceCmd = &cobra.Command{
+	Use:   "delete [namespace]",
+	Short: "Delete a namespace and all of its workflows",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmNamespace(args[0], "delete")
+
+		namespaceManager := newNamespaceManager()
+		defer namespaceManager.Close()
+
+		if err := namespaceManager.DeleteNamespace(context.Background(), args[0]); err != nil {
+			log.Fatalf("Failed to delete namespace: %v", err)
+		}
+		render(actionResult{Action: "deleted", Target: args[0]}, func() {
+			fmt.Printf("✅ Namespace '%s' deleted\n", args[0])
+		})
+	},
+}
+
+func newNamespaceManager() *temporal.NamespaceManager {
+	return temporal.NewNamespaceManager(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	})
+}
+
+// confirmNamespace asks to type the namespace name before an action that cannot be undone, unless --yes
+func confirmNamespace(name string, action string) {
+	if namespaceConfirm {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "⚠️  This will %s namespace '%s'. Type the namespace name to confirm: ", action, name)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(answer) != name {
+		log.Fatalf("Namespace name did not match, not going to %s '%s'", action, name)
+	}
+}
+
+func printNamespace(description temporal.NamespaceDescription) {
+	fmt.Printf("Namespace:           %s\n", description.Name)
+	fmt.Printf("ID:                  %s\n", description.ID)
+	fmt.Printf("State:               %s\n", description.State)
+	fmt.Printf("Description:         %s\n", description.Description)
+	fmt.Printf("Owner email:         %s\n", description.OwnerEmail)
+	fmt.Printf("Retention:           %d days\n", int(description.Retention.Hours()/24))
+	fmt.Printf("History archival:    %s %s\n", description.HistoryArchival.State, description.HistoryArchival.URI)
+	fmt.Printf("Visibility archival: %s %s\n", description.VisibilityArchival.State, description.VisibilityArchival.URI)
+	if description.Global {
+		fmt.Printf("Active cluster:      %s\n", description.ActiveCluster)
+	}
+	for _, key := range slices.Sorted(maps.Keys(description.Data)) {
+		fmt.Printf("Data:                %s=%s\n", key, description.Data[key])
+	}
+	if len(description.SearchAttributes) > 0 {
+		fmt.Println("Search attributes:")
+		for _, name := range slices.Sorted(maps.Keys(description.SearchAttributes)) {
+			fmt.Printf("  %-30s %s\n", name, description.SearchAttributes[name])
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(namespaceCmd)
 	namespaceCmd.AddCommand(registerNamespaceCmd)
 	namespaceCmd.AddCommand(listNamespacesCmd)
+	namespaceCmd.AddCommand(describeNamespaceCmd)
+	namespaceCmd.AddCommand(updateNamespaceCmd)
+	namespaceCmd.AddCommand(deprecateNamespaceCmd)
+	namespaceCmd.AddCommand(deleteNamespaceCmd)
 
 	// Flags for register command
 	registerNamespaceCmd.Flags().StringVar(&namespaceName, "name", "", "Namespace name (required)")
 	registerNamespaceCmd.Flags().StringVarP(&namespaceDesc, "description", "d", "", "Namespace description")
 	registerNamespaceCmd.Flags().StringVarP(&retentionDays, "retention", "r", "7", "Workflow execution retention period in days")
 	registerNamespaceCmd.MarkFlagRequired("name")
+
+	// Flags for list command
+	listNamespacesCmd.Flags().StringVar(&namespacePrefix, "prefix", "", "Only list namespaces whose name starts with this")
+	listNamespacesCmd.Flags().StringVar(&namespaceState, "state", "", "Only list namespaces in this state: registered, deprecated or deleted")
+
+	// Flags for update command
+	updateNamespaceCmd.Flags().StringVarP(&namespaceDesc, "description", "d", "", "New namespace description")
+	updateNamespaceCmd.Flags().StringVar(&namespaceOwner, "owner-email", "", "New owner email")
+	updateNamespaceCmd.Flags().StringVarP(&namespaceRetention, "retention", "r", "", "New workflow execution retention period in days")
+	updateNamespaceCmd.Flags().StringToStringVar(&namespaceData, "data", nil, "Custom data to set, e.g. team=payments,tier=gold")
+
+	deprecateNamespaceCmd.Flags().BoolVarP(&namespaceConfirm, "yes", "y", false, "Do not ask for confirmation")
+	deleteNamespaceCmd.Flags().BoolVarP(&namespaceConfirm, "yes", "y", false, "Do not ask for confirmation")
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/repository"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ordersResolution   string
+	ordersBusinessUnit string
+	ordersLimit        int
+)
+
+var ordersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Order history commands",
+	Long:  `Commands to read the final state of orders recorded by the worker in the order database.`,
+}
+
+var listOrdersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List concluded orders, most recent first",
+	Run: func(cmd *cobra.Command, args []string) {
+		orderRepository := openOrderRepository()
+		defer orderRepository.Close()
+
+		records, err := orderRepository.ListOrders(context.Background(), repository.OrderFilter{
+			Resolution:   ordersResolution,
+			BusinessUnit: ordersBusinessUnit,
+			Limit:        ordersLimit,
+		})
+		if err != nil {
+			log.Fatalf("Unable to list orders: %v", err)
+		}
+
+		render(orEmpty(records), func() {
+			fmt.Printf("%-20s %-16s %-20s %-12s %-22s %s\n", "ORDER", "RESOLUTION", "RESOLVED BY", "UNIT", "RESOLVED AT", "PATH")
+			for _, record := range records {
+				fmt.Printf("%-20s %-16s %-20s %-12s %-22s %s\n", record.OrderID, record.Resolution, record.ResolvedBy,
+					record.BusinessUnit, record.ResolvedAt.Format(time.RFC3339), strings.Join(record.EscalationPath, " > "))
+			}
+			fmt.Printf("\nTotal: %d orders\n", len(records))
+		})
+	},
+}
+
+var showOrderCmd = &cobra.Command{
+	Use:   "show [order-id]",
+	Short: "Show every recorded conclusion of an order",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		orderRepository := openOrderRepository()
+		defer orderRepository.Close()
+
+		records, err := orderRepository.GetOrder(context.Background(), args[0])
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Fatalf("Order %s has not been recorded", args[0])
+		}
+		if err != nil {
+			log.Fatalf("Unable to get order: %v", err)
+		}
+
+		render(orEmpty(records), func() {
+			for i, record := range records {
+				if i > 0 {
+					fmt.Println()
+				}
+				printOrderRecord(record)
+			}
+		})
+	},
+}
+
+func openOrderRepository() repository.OrderRepository {
+	orderRepository, err := repository.Open(databaseDSN)
+	if err != nil {
+		log.Fatalf("Unable to open order database: %v", err)
+	}
+	return orderRepository
+}
+
+func printOrderRecord(record models.OrderRecord) {
+	fmt.Printf("Order:           %s\n", record.OrderID)
+	fmt.Printf("Workflow:        %s (run %s)\n", record.WorkflowID, record.RunID)
+	fmt.Printf("Resolution:      %s\n", record.Resolution)
+	fmt.Printf("Resolved by:     %s\n", record.ResolvedBy)
+	fmt.Printf("Business unit:   %s\n", record.BusinessUnit)
+	fmt.Printf("Priority:        %s\n", record.Priority)
+	if !record.StartedAt.IsZero() {
+		fmt.Printf("Started at:      %s\n", record.StartedAt.Format(time.RFC3339))
+	}
+	fmt.Printf("Resolved at:     %s\n", record.ResolvedAt.Format(time.RFC3339))
+	fmt.Printf("Escalation path: %s\n", strings.Join(record.EscalationPath, " > "))
+	if len(record.ErrorHistory) > 0 {
+		fmt.Println("Errors:")
+		for _, message := range record.ErrorHistory {
+			fmt.Printf("  - %s\n", message)
+		}
+	}
+}
+
+func init() {
+	clientCmd.AddCommand(ordersCmd)
+
+	ordersCmd.AddCommand(listOrdersCmd)
+	ordersCmd.AddCommand(showOrderCmd)
+
+	ordersCmd.PersistentFlags().StringVar(&databaseDSN, "database", "orders.db", "Order database: a SQLite file path or a postgres:// URL")
+
+	listOrdersCmd.Flags().StringVarP(&ordersResolution, "resolution", "r", "", "Only list orders with this resolution")
+	listOrdersCmd.Flags().StringVarP(&ordersBusinessUnit, "business-unit", "b", "", "Only list orders of this business unit")
+	listOrdersCmd.Flags().IntVar(&ordersLimit, "limit", 50, "Maximum number of orders to list")
+}
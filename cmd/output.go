@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+var outputFormat string
+
+// actionResult is the output of a command that changes something and has nothing else to show
+type actionResult struct {
+	Action string `json:"action"` // what was done, e.g. paused or signalled
+	Target string `json:"target"` // the namespace, workflow, schedule, provider or scope acted on
+	Detail string `json:"detail,omitempty"`
+}
+
+// validateOutput rejects an unknown --output before a command runs
+func validateOutput() error {
+	if !slices.Contains([]string{OutputText, OutputJSON, OutputYAML}, outputFormat) {
+		return fmt.Errorf("unknown output %q, expected text, json or yaml", outputFormat)
+	}
+	return nil
+}
+
+// render writes the result of a command to stdout: as JSON or YAML, with the json field names of value, or
+// as text with printText. Logs go to stderr, so stdout only holds the result
+func render(value any, printText func()) {
+	switch outputFormat {
+	case OutputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			log.Fatalf("Failed to write output: %v", err)
+		}
+	case OutputYAML:
+		// JSON is YAML, so the YAML output keeps the field names and order of the JSON output
+		data, err := json.Marshal(value)
+		if err != nil {
+			log.Fatalf("Failed to write output: %v", err)
+		}
+		var document yaml.Node
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			log.Fatalf("Failed to write output: %v", err)
+		}
+		blockStyle(&document)
+
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&document); err != nil {
+			log.Fatalf("Failed to write output: %v", err)
+		}
+	default:
+		printText()
+	}
+}
+
+// blockStyle drops the flow style and quoting the JSON input gave a YAML document
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// orEmpty makes an empty list render as [] rather than null
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/pause"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	pauseBusinessUnit string
+	pauseEnvironment  string
+	pauseOperator     string
+	pauseReason       string
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Hold order processing for a business unit or environment",
+	Long: `Hold the order workflows of a business unit or environment before their next query, e.g. during a bank maintenance window.
+Without --business-unit or --environment, list the paused business units and environments.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		pauseClient, workflowManager := newPauseClient()
+		defer workflowManager.Close()
+
+		if pauseBusinessUnit == "" && pauseEnvironment == "" {
+			paused, err := pauseClient.PausedScopes(context.Background())
+			if err != nil {
+				log.Fatalf("Unable to list paused scopes: %v", err)
+			}
+			render(orEmpty(paused), func() {
+				fmt.Printf("%-14s %-16s %-8s %-22s %-12s %s\n", "SCOPE", "VALUE", "WAITING", "PAUSED AT", "OPERATOR", "REASON")
+				for _, scope := range paused {
+					fmt.Printf("%-14s %-16s %-8d %-22s %-12s %s\n", scope.Scope, scope.Value, scope.Waiting,
+						scope.PausedAt.Format(time.RFC3339), scope.Operator, scope.Reason)
+				}
+			})
+			return
+		}
+
+		var results []actionResult
+		for _, request := range pauseRequests() {
+			if err := pauseClient.Pause(context.Background(), request); err != nil {
+				log.Fatalf("Unable to pause %s %s: %v", request.Scope, request.Value, err)
+			}
+			results = append(results, actionResult{Action: "paused", Target: request.Scope + "=" + request.Value, Detail: request.Reason})
+		}
+		render(results, func() {
+			for _, result := range results {
+				fmt.Printf("Paused processing for %s\n", result.Target)
+			}
+		})
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume order processing for a paused business unit or environment",
+	Run: func(cmd *cobra.Command, args []string) {
+		if pauseBusinessUnit == "" && pauseEnvironment == "" {
+			log.Fatalf("--business-unit or --environment is required")
+		}
+
+		pauseClient, workflowManager := newPauseClient()
+		defer workflowManager.Close()
+
+		var results []actionResult
+		for _, request := range pauseRequests() {
+			if err := pauseClient.Resume(context.Background(), request); err != nil {
+				log.Fatalf("Unable to resume %s %s: %v", request.Scope, request.Value, err)
+			}
+			results = append(results, actionResult{Action: "resumed", Target: request.Scope + "=" + request.Value, Detail: request.Reason})
+		}
+		render(results, func() {
+			for _, result := range results {
+				fmt.Printf("Resumed processing for %s\n", result.Target)
+			}
+		})
+	},
+}
+
+func newPauseClient() (*pause.Client, *temporal.WorkflowManager) {
+	workflowManager := temporal.NewWorkflowManager(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	})
+	return pause.NewClient(workflowManager, QueueQueryOrder), workflowManager
+}
+
+func pauseRequests() []models.PauseRequest {
+	var requests []models.PauseRequest
+	if pauseBusinessUnit != "" {
+		requests = append(requests, models.PauseRequest{Scope: models.PauseScopeBusinessUnit, Value: pauseBusinessUnit})
+	}
+	if pauseEnvironment != "" {
+		requests = append(requests, models.PauseRequest{Scope: models.PauseScopeEnvironment, Value: pauseEnvironment})
+	}
+	for i := range requests {
+		requests[i].Operator = pauseOperator
+		requests[i].Reason = pauseReason
+	}
+	return requests
+}
+
+func init() {
+	clientCmd.AddCommand(pauseCmd)
+	clientCmd.AddCommand(resumeCmd)
+
+	for _, cmd := range []*cobra.Command{pauseCmd, resumeCmd} {
+		cmd.Flags().StringVarP(&pauseBusinessUnit, "business-unit", "b", "", "Business unit to pause or resume")
+		cmd.Flags().StringVarP(&pauseEnvironment, "environment", "e", "", "Environment to pause or resume")
+		cmd.Flags().StringVar(&pauseOperator, "operator", os.Getenv("USER"), "Operator pausing or resuming")
+		cmd.Flags().StringVarP(&pauseReason, "reason", "m", "", "Why processing is paused or resumed")
+	}
+}
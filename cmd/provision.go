@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"temporal-playground/internal/provision"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	provisionFile   string
+	provisionDryRun bool
+)
+
+var provisionCmd = &cobra.Command{
+	Use:   "provision",
+	Short: "Declarative environment provisioning",
+	Long: `Commands to bring an environment to the state declared in a YAML file: its namespaces with their
+retention and description, custom search attributes and recurring payment schedules.`,
+}
+
+var planProvisionCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what apply would change",
+	Run: func(cmd *cobra.Command, args []string) {
+		provisioner, plan := planEnvironment()
+		defer provisioner.Close()
+
+		render(provisionResult{Plan: plan}, func() { printPlan(plan) })
+	},
+}
+
+var applyProvisionCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update the namespaces, search attributes and schedules of an environment file",
+	Long: `Create or update the namespaces, search attributes and schedules of an environment file.
+Apply is idempotent: namespaces are only updated where they differ, and existing search attributes are kept.
+Existing schedules get the contract, policies, priority and schedule of the file where they differ, and the
+file's terms less the payments already made; whether they are paused is kept. It refuses to run when a search attribute exists with another type.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		provisioner, plan := planEnvironment()
+		defer provisioner.Close()
+
+		if provisionDryRun || plan.Pending() == 0 {
+			render(provisionResult{Plan: plan}, func() { printPlan(plan) })
+			return
+		}
+		if outputFormat == OutputText {
+			printPlan(plan)
+		}
+		if err := provisioner.Apply(context.Background(), plan); err != nil {
+			log.Fatalf("Unable to apply environment: %v", err)
+		}
+		render(provisionResult{Plan: plan, Applied: true}, func() {
+			fmt.Printf("\nApplied %d changes\n", plan.Pending())
+		})
+	},
+}
+
+// provisionResult is the output of provision plan and apply
+type provisionResult struct {
+	provision.Plan
+	Applied bool `json:"applied"` // false for plan, --dry-run or when nothing differs
+}
+
+func planEnvironment() (*provision.Provisioner, provision.Plan) {
+	environment, err := provision.LoadEnvironment(provisionFile)
+	if err != nil {
+		log.Fatalf("Unable to load environment: %v", err)
+	}
+
+	provisioner := provision.NewProvisioner(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	}, orderServiceConfig())
+
+	plan, err := provisioner.Plan(context.Background(), environment)
+	if err != nil {
+		provisioner.Close()
+		log.Fatalf("Unable to plan environment: %v", err)
+	}
+	plan.Changes = orEmpty(plan.Changes)
+	return provisioner, plan
+}
+
+func printPlan(plan provision.Plan) {
+	fmt.Printf("%-2s %-18s %-20s %-28s %s\n", "", "KIND", "NAMESPACE", "NAME", "DETAIL")
+	for _, change := range plan.Changes {
+		fmt.Printf("%-2s %-18s %-20s %-28s %s\n", change.Action, change.Kind, change.Namespace, change.Name, change.Detail)
+	}
+	fmt.Printf("\nPlan: %d to change, %d unchanged, %d conflicts\n", plan.Pending(),
+		len(plan.Changes)-plan.Pending()-len(plan.Conflicts()), len(plan.Conflicts()))
+}
+
+func init() {
+	rootCmd.AddCommand(provisionCmd)
+
+	provisionCmd.AddCommand(planProvisionCmd)
+	provisionCmd.AddCommand(applyProvisionCmd)
+
+	provisionCmd.PersistentFlags().StringVarP(&provisionFile, "file", "f", "", "Environment file, e.g. env.yaml")
+	provisionCmd.MarkPersistentFlagRequired("file")
+
+	applyProvisionCmd.Flags().BoolVar(&provisionDryRun, "dry-run", false, "Print the plan without applying it")
+}
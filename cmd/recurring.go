@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/repository"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	recurringNote     string
+	recurringFrom     string
+	recurringTo       string
+	recurringTerms    int
+	recurringInterval time.Duration
+)
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Recurring payment schedule commands",
+	Long:  `Commands to inspect and operate the recurring payment schedules created with create-recurring-payment.`,
+}
+
+var listRecurringCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the recurring payment schedules",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		payments, err := service.ListRecurringPayments(context.Background())
+		if err != nil {
+			log.Fatalf("Unable to list recurring payments: %v", err)
+		}
+
+		render(orEmpty(payments), func() {
+			fmt.Printf("%-24s %-8s %-12s %-22s %s\n", "CONSENT", "PAUSED", "INTERVAL", "NEXT PAYMENT", "NOTE")
+			for _, payment := range payments {
+				fmt.Printf("%-24s %-8t %-12s %-22s %s\n", payment.ConsentID, payment.Paused, payment.Interval,
+					nextPaymentTime(payment), payment.Note)
+			}
+			fmt.Printf("\nTotal: %d recurring payments\n", len(payments))
+		})
+	},
+}
+
+var describeRecurringCmd = &cobra.Command{
+	Use:   "describe [consent-id]",
+	Short: "Show the next payments, remaining terms and recent payment results of a schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		payment, err := service.GetRecurringPayment(context.Background(), args[0])
+		if err != nil {
+			log.Fatalf("Unable to describe recurring payment: %v", err)
+		}
+
+		render(payment, func() {
+			remaining := "infinite"
+			if payment.LimitedTerms {
+				remaining = fmt.Sprintf("%d", payment.RemainingTerms)
+			}
+			fmt.Printf("Consent:         %s\n", payment.ConsentID)
+			if contract := payment.Contract; contract != nil {
+				if contract.CustomerID != "" {
+					fmt.Printf("Customer:        %s\n", contract.CustomerID)
+				}
+				if contract.Amount.Currency != "" {
+					fmt.Printf("Amount:          %s\n", contract.Amount)
+				}
+				if contract.Status != "" {
+					fmt.Printf("Status:          %s %s\n", contract.Status, contract.StatusReason)
+				}
+				for _, key := range slices.Sorted(maps.Keys(contract.Metadata)) {
+					fmt.Printf("Metadata:        %s=%s\n", key, contract.Metadata[key])
+				}
+			}
+			fmt.Printf("Interval:        %s\n", payment.Interval)
+			fmt.Printf("Overlap:         %s\n", payment.Overlap)
+			fmt.Printf("Catch-up window: %s\n", payment.CatchupWindow)
+			fmt.Printf("Pause on fail:   %t\n", payment.PauseOnFailure)
+			if payment.Priority != "" {
+				fmt.Printf("Priority:        %s\n", payment.Priority)
+			}
+			fmt.Printf("Paused:          %t\n", payment.Paused)
+			if payment.Note != "" {
+				fmt.Printf("Note:            %s\n", payment.Note)
+			}
+			fmt.Printf("Payments made:   %d\n", payment.PaymentsMade)
+			fmt.Printf("Remaining terms: %s\n", remaining)
+
+			fmt.Printf("\nNext payments:\n")
+			for _, next := range payment.NextPaymentTimes {
+				fmt.Printf("  %s\n", next.Format(time.RFC3339))
+			}
+
+			fmt.Printf("\nRecent payments:\n")
+			fmt.Printf("  %-22s %-22s %-12s %s\n", "SCHEDULED AT", "STARTED AT", "STATUS", "WORKFLOW")
+			for _, result := range payment.RecentPayments {
+				fmt.Printf("  %-22s %-22s %-12s %s\n", result.ScheduledAt.Format(time.RFC3339), result.StartedAt.Format(time.RFC3339),
+					strings.TrimPrefix(result.Status, "WORKFLOW_EXECUTION_STATUS_"), result.WorkflowID)
+			}
+		})
+	},
+}
+
+var pauseRecurringCmd = &cobra.Command{
+	Use:   "pause [consent-id]",
+	Short: "Pause a recurring payment schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		if err := service.PauseRecurringPayment(context.Background(), args[0], recurringNote); err != nil {
+			log.Fatalf("Unable to pause recurring payment: %v", err)
+		}
+		render(actionResult{Action: "paused", Target: args[0], Detail: recurringNote}, func() {
+			fmt.Printf("Paused recurring payment %s\n", args[0])
+		})
+	},
+}
+
+var unpauseRecurringCmd = &cobra.Command{
+	Use:   "unpause [consent-id]",
+	Short: "Unpause a recurring payment schedule; payments missed while paused are not taken",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		if err := service.UnpauseRecurringPayment(context.Background(), args[0], recurringNote); err != nil {
+			log.Fatalf("Unable to unpause recurring payment: %v", err)
+		}
+		render(actionResult{Action: "unpaused", Target: args[0], Detail: recurringNote}, func() {
+			fmt.Printf("Unpaused recurring payment %s\n", args[0])
+		})
+	},
+}
+
+var triggerRecurringCmd = &cobra.Command{
+	Use:   "trigger [consent-id]",
+	Short: "Take a payment now, outside of the schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		if err := service.TriggerRecurringPayment(context.Background(), args[0]); err != nil {
+			log.Fatalf("Unable to trigger recurring payment: %v", err)
+		}
+		render(actionResult{Action: "triggered", Target: args[0]}, func() {
+			fmt.Printf("Triggered a payment of recurring payment %s\n", args[0])
+		})
+	},
+}
+
+var backfillRecurringCmd = &cobra.Command{
+	Use:   "backfill [consent-id]",
+	Short: "Take the payments the schedule would have taken between --from and --to",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := time.Parse(time.RFC3339, recurringFrom)
+		if err != nil {
+			log.Fatalf("--from must be an RFC3339 time: %v", err)
+		}
+		to, err := time.Parse(time.RFC3339, recurringTo)
+		if err != nil {
+			log.Fatalf("--to must be an RFC3339 time: %v", err)
+		}
+
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		if err := service.BackfillRecurringPayment(context.Background(), args[0], from, to); err != nil {
+			log.Fatalf("Unable to backfill recurring payment: %v", err)
+		}
+		render(actionResult{Action: "backfilled", Target: args[0], Detail: recurringFrom + "/" + recurringTo}, func() {
+			fmt.Printf("Backfilled recurring payment %s from %s to %s\n", args[0], recurringFrom, recurringTo)
+		})
+	},
+}
+
+var updateRecurringCmd = &cobra.Command{
+	Use:   "update [consent-id]",
+	Short: "Change the remaining terms and/or the interval of a recurring payment schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var request orders.UpdateRecurringPaymentRequest
+		if cmd.Flags().Changed("terms") {
+			request.Terms = &recurringTerms
+		}
+		if cmd.Flags().Changed("interval") {
+			request.Interval = &recurringInterval
+		}
+
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		payment, err := service.UpdateRecurringPayment(context.Background(), args[0], request)
+		if err != nil {
+			log.Fatalf("Unable to update recurring payment: %v", err)
+		}
+		render(payment, func() {
+			fmt.Printf("Recurring payment %s: interval %s, next payment %s\n", payment.ConsentID, payment.Interval, nextPaymentTime(payment))
+		})
+	},
+}
+
+var chargesRecurringCmd = &cobra.Command{
+	Use:   "charges [consent-id]",
+	Short: "List the terms charged for a recurring payment, as recorded in the charge ledger",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		chargeRepository, err := repository.Open(databaseDSN)
+		if err != nil {
+			log.Fatalf("Unable to open order database: %v", err)
+		}
+		defer chargeRepository.Close()
+
+		charges, err := chargeRepository.ListCharges(context.Background(), args[0])
+		if err != nil {
+			log.Fatalf("Unable to list recurring payment charges: %v", err)
+		}
+
+		render(orEmpty(charges), func() {
+			fmt.Printf("%-22s %-14s %-22s %-8s %s\n", "SCHEDULED AT", "AMOUNT", "CHARGED AT", "ATTEMPT", "WORKFLOW")
+			for _, charge := range charges {
+				fmt.Printf("%-22s %-14s %-22s %-8d %s\n", charge.ScheduledAt.Format(time.RFC3339), charge.Amount,
+					charge.ChargedAt.Format(time.RFC3339), charge.Attempt, charge.WorkflowID)
+			}
+			fmt.Printf("\nTotal: %d charges\n", len(charges))
+		})
+	},
+}
+
+func newOrderService() (*orders.Service, *temporal.WorkflowManager) {
+	workflowManager := temporal.NewWorkflowManager(client.Options{
+		HostPort:  hostPort,
+		Namespace: namespace,
+	})
+	return orders.NewService(workflowManager, orderServiceConfig()), workflowManager
+}
+
+func nextPaymentTime(payment orders.RecurringPayment) string {
+	if len(payment.NextPaymentTimes) == 0 {
+		return "-"
+	}
+	return payment.NextPaymentTimes[0].Format(time.RFC3339)
+}
+
+func init() {
+	clientCmd.AddCommand(recurringCmd)
+
+	recurringCmd.AddCommand(listRecurringCmd)
+	recurringCmd.AddCommand(describeRecurringCmd)
+	recurringCmd.AddCommand(pauseRecurringCmd)
+	recurringCmd.AddCommand(unpauseRecurringCmd)
+	recurringCmd.AddCommand(triggerRecurringCmd)
+	recurringCmd.AddCommand(backfillRecurringCmd)
+	recurringCmd.AddCommand(updateRecurringCmd)
+	recurringCmd.AddCommand(chargesRecurringCmd)
+
+	pauseRecurringCmd.Flags().StringVarP(&recurringNote, "note", "m", "", "Why the schedule is paused")
+	unpauseRecurringCmd.Flags().StringVarP(&recurringNote, "note", "m", "", "Why the schedule is unpaused")
+
+	backfillRecurringCmd.Flags().StringVar(&recurringFrom, "from", "", "Start of the backfill window (RFC3339)")
+	backfillRecurringCmd.Flags().StringVar(&recurringTo, "to", "", "End of the backfill window (RFC3339)")
+	backfillRecurringCmd.MarkFlagRequired("from")
+	backfillRecurringCmd.MarkFlagRequired("to")
+
+	chargesRecurringCmd.Flags().StringVar(&databaseDSN, "database", "orders.db", "Order database: a SQLite file path or a postgres:// URL")
+
+	updateRecurringCmd.Flags().IntVarP(&recurringTerms, "terms", "r", 0, "Number of remaining payment terms (0 means infinite)")
+	updateRecurringCmd.Flags().DurationVar(&recurringInterval, "interval", 0, "New payment interval, e.g. 720h; replaces a calendar or cron schedule")
+}
@@ -16,11 +16,14 @@ var rootCmd = &cobra.Command{
 	Use:   "temporal-playground",
 	Short: "Temporal workflow application",
 	Long:  `A Temporal workflow application with CLI commands to manage workers and workflows.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutput()
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -28,4 +31,5 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Temporal namespace (required)")
 	rootCmd.PersistentFlags().StringVar(&hostPort, "hostport", "localhost:7233", "Temporal server host:port")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", OutputText, "Output format of command results: text, json or yaml; logs always go to stderr")
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"temporal-playground/internal/api"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/temporal"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var serverListenAddr string
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Serve the HTTP/JSON order API",
+	Long:  `Serve a REST API for payment services to submit orders, check their status, resolve manual orders and manage recurring payments.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		server := api.NewServer(orders.NewService(workflowManager, orderServiceConfig()))
+
+		log.Printf("Order API listening on http://%s (spec at /openapi.json)", serverListenAddr)
+		if err := http.ListenAndServe(serverListenAddr, server.Handler()); err != nil {
+			log.Fatalf("API server stopped: %v", err)
+		}
+	},
+}
+
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI spec of the order API",
+	Run: func(cmd *cobra.Command, args []string) {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(api.NewServer(nil).OpenAPISpec()); err != nil {
+			log.Fatalf("Failed to write OpenAPI spec: %v", err)
+		}
+	},
+}
+
+func orderServiceConfig() orders.Config {
+	return orders.Config{
+		QueryOrderQueue:        QueueQueryOrder,
+		RecurringScheduleQueue: QueueRecurringSchedule,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(serverCmd)
+	serverCmd.AddCommand(openAPICmd)
+
+	serverCmd.Flags().StringVar(&serverListenAddr, "listen", "localhost:8081", "Address for the API to listen on")
+}
@@ -13,7 +13,11 @@ var versionCmd = &cobra.Command{
 	Short: "Print the version number",
 	Long:  `Print the version number of temporal-playground.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("temporal-playground version %s\n", version)
+		render(struct {
+			Version string `json:"version"`
+		}{version}, func() {
+			fmt.Printf("temporal-playground version %s\n", version)
+		})
 	},
 }
 
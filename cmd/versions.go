@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/versions"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+var (
+	versionsWorkflowTypes []string
+	versionsChangeIDs     []string
+	versionsClosedWithin  time.Duration
+	versionsLimit         int
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "workflow.GetVersion lifecycle commands",
+	Long:  `Commands to find out which workflow.GetVersion branches are still in use before changing them.`,
+}
+
+var versionsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report the versions of each GetVersion change ID still used by open and recently closed workflows",
+	Long: `Scan the open workflows and the workflows closed within --closed-within through visibility, read the
+GetVersion markers from the histories of those whose TemporalChangeVersion search attribute names a change ID, and tell which branches of each change ID, e.g. recurring-payment,
+can be dropped and when minSupported can be raised.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer workflowManager.Close()
+
+		report, err := versions.NewReporter(workflowManager).Report(context.Background(), versions.ReportOptions{
+			WorkflowTypes: versionsWorkflowTypes,
+			ChangeIDs:     versionsChangeIDs,
+			ClosedWithin:  versionsClosedWithin,
+			Limit:         versionsLimit,
+		})
+		if err != nil {
+			log.Fatalf("Unable to report workflow versions: %v", err)
+		}
+
+		report.Changes = orEmpty(report.Changes)
+		render(report, func() {
+			fmt.Printf("Scanned %d open workflows and workflows closed since %s\n", report.Scanned, report.Since.Format(time.RFC3339))
+			if report.Truncated {
+				fmt.Printf("The scan stopped at --limit %d, older branches may still be in use\n", versionsLimit)
+			}
+			for _, change := range report.Changes {
+				fmt.Printf("\n%s / %s\n", change.WorkflowType, change.ChangeID)
+				fmt.Printf("  %-10s %-8s %s\n", "VERSION", "OPEN", "CLOSED")
+				for _, usage := range change.Versions {
+					version := fmt.Sprint(usage.Version)
+					if usage.Version == int(workflow.DefaultVersion) {
+						version = "default"
+					}
+					fmt.Printf("  %-10s %-8d %d\n", version, usage.Open, usage.Closed)
+				}
+				for _, advice := range change.Advice {
+					fmt.Printf("  - %s\n", advice)
+				}
+			}
+			if len(report.Changes) == 0 {
+				fmt.Println("\nNo GetVersion markers found")
+			}
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionsCmd)
+	versionsCmd.AddCommand(versionsReportCmd)
+
+	versionsReportCmd.Flags().StringSliceVarP(&versionsWorkflowTypes, "workflow-type", "t", nil, "Only scan these workflow types, e.g. RegisterRecurringPayment")
+	versionsReportCmd.Flags().StringSliceVar(&versionsChangeIDs, "change-id", nil, "Only report these change IDs, e.g. recurring-payment")
+	versionsReportCmd.Flags().DurationVar(&versionsClosedWithin, "closed-within", 7*24*time.Hour, "Also scan workflows closed this recently; use the namespace retention")
+	versionsReportCmd.Flags().IntVar(&versionsLimit, "limit", 1000, "Most workflows to scan, 0 means all")
+}
@@ -4,11 +4,26 @@ import (
 	"log"
 	"sync"
 	"temporal-playground/internal/activities"
+	"temporal-playground/internal/breaker"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/pause"
+	"temporal-playground/internal/repository"
 	"temporal-playground/internal/temporal"
 	"temporal-playground/internal/workflows"
 
 	"github.com/spf13/cobra"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+var (
+	webhookConfigFile   string
+	databaseDSN         string
+	rateLimitConfigFile string
+	breakerConfigFile   string
+	deploymentName      string
+	buildID             string
 )
 
 // start all workers: testing purpose only, do not do this in prod
@@ -20,24 +35,89 @@ var workerCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Printf("Starting Temporal worker in namespace: %s", namespace)
 
+		webhookConfig := activities.WebhookConfig{}
+		if webhookConfigFile != "" {
+			var err error
+			if webhookConfig, err = activities.LoadWebhookConfig(webhookConfigFile); err != nil {
+				log.Fatalf("Unable to load webhook config: %v", err)
+			}
+		}
+
+		orderRepository, err := repository.Open(databaseDSN)
+		if err != nil {
+			log.Fatalf("Unable to open order database: %v", err)
+		}
+		defer orderRepository.Close()
+		// deliveries are recorded in the order database, so the delivery log covers every worker
+		notifier := activities.NewNotifier(webhookConfig, orderRepository)
+
+		rateLimitConfig := activities.RateLimitConfig{}
+		if rateLimitConfigFile != "" {
+			if rateLimitConfig, err = activities.LoadRateLimitConfig(rateLimitConfigFile); err != nil {
+				log.Fatalf("Unable to load rate limit config: %v", err)
+			}
+		}
+
+		breakerConfig := activities.BreakerConfig{Default: activities.DefaultBreakerPolicy}
+		if breakerConfigFile != "" {
+			if breakerConfig, err = activities.LoadBreakerConfig(breakerConfigFile); err != nil {
+				log.Fatalf("Unable to load breaker config: %v", err)
+			}
+		}
+		// the circuit breakers and the pause control are workflows on the query order task queue, shared by every worker;
+		// the same client pauses the schedules of delinquent recurring payments
+		controlManager := temporal.NewWorkflowManager(client.Options{
+			HostPort:  hostPort,
+			Namespace: namespace,
+		})
+		defer controlManager.Close()
+		breakers := activities.NewBreakers(breaker.NewClient(controlManager, QueueQueryOrder), breakerConfig)
+		pauseControl := activities.NewPauseControl(pause.NewClient(controlManager, QueueQueryOrder))
+
+		recurringActivities := activities.NewRecurringPaymentActivities(orders.NewService(controlManager, orderServiceConfig()), orderRepository)
+		orderActivities := activities.NewOrderActivities(orderRepository, activities.NewRateLimiter(rateLimitConfig), breakers)
+
+		// worker-level limits on how fast each task queue hands out activities
+		workerOptions := func(taskQueue string) worker.Options {
+			limit := rateLimitConfig.Workers[taskQueue]
+			options := worker.Options{
+				TaskQueueActivitiesPerSecond: limit.TaskQueueActivitiesPerSecond,
+				WorkerActivitiesPerSecond:    limit.WorkerActivitiesPerSecond,
+			}
+			if buildID != "" {
+				// only the deployment's current or ramping build receives new workflow tasks, see the deployment commands
+				options.DeploymentOptions = worker.DeploymentOptions{
+					UseVersioning: true,
+					Version: worker.WorkerDeploymentVersion{
+						DeploymentName: deploymentName,
+						BuildId:        buildID,
+					},
+				}
+			}
+			return options
+		}
+		if buildID != "" {
+			log.Printf("Workers join deployment %s as build %s", deploymentName, buildID)
+		}
+
 		// Create all workers
 		workers := []*temporal.WorkerManager{
 			temporal.NewWorkerManager(client.Options{
 				HostPort:  hostPort,
 				Namespace: namespace,
-			}, QueueQueryOrder),
+			}, QueueQueryOrder, workerOptions(QueueQueryOrder)),
 			temporal.NewWorkerManager(client.Options{
 				HostPort:  hostPort,
 				Namespace: namespace,
-			}, QueueStaleOrder),
+			}, QueueStaleOrder, workerOptions(QueueStaleOrder)),
 			temporal.NewWorkerManager(client.Options{
 				HostPort:  hostPort,
 				Namespace: namespace,
-			}, QueueManualHandle),
+			}, QueueManualHandle, workerOptions(QueueManualHandle)),
 			temporal.NewWorkerManager(client.Options{
 				HostPort:  hostPort,
 				Namespace: namespace,
-			}, QueueRecurringSchedule),
+			}, QueueRecurringSchedule, workerOptions(QueueRecurringSchedule)),
 		}
 
 		// Ensure all workers are closed on exit
@@ -47,31 +127,46 @@ var workerCmd = &cobra.Command{
 			}
 		}()
 
-		// Register workflows and activities for each worker
+		// Register workflows and activities for each worker. Only RegisterRecurringPayment, which never waits, is pinned
+		// to the build that started it; the others, Stale included, can wait indefinitely while processing is paused or
+		// a circuit breaker is open, so they auto-upgrade to the current build behind their GetVersion guards
 		// Query Order Worker (index 0)
-		workers[0].RegisterWorkflow(workflows.QueryOrder)
-		workers[0].RegisterActivity(activities.QueryOrder)
-		workers[0].RegisterActivity(activities.FinalizeStaleWorkflow)
-		workers[0].RegisterActivity(activities.ConcludeQueryOrder)
+		workers[0].RegisterWorkflow(workflows.QueryOrder, workflow.VersioningBehaviorAutoUpgrade)
+		workers[0].RegisterWorkflow(workflows.OrderLifecycle, workflow.VersioningBehaviorAutoUpgrade)
+		workers[0].RegisterWorkflow(workflows.CircuitBreaker, workflow.VersioningBehaviorAutoUpgrade)
+		workers[0].RegisterWorkflow(workflows.ProcessingControl, workflow.VersioningBehaviorAutoUpgrade)
+		workers[0].RegisterActivity(orderActivities.QueryOrder)
+		workers[0].RegisterActivity(orderActivities.FinalizeStaleWorkflow)
+		workers[0].RegisterActivity(orderActivities.ConcludeQueryOrder)
+		workers[0].RegisterActivity(notifier.NotifyOrderResolution)
+		workers[0].RegisterActivity(pauseControl.PausedScope)
 
 		// Stale Order Worker (index 1)
-		workers[1].RegisterWorkflow(workflows.Stale)
-		workers[1].RegisterActivity(activities.QueryOrder)
-		workers[1].RegisterActivity(activities.FinalizeStaleWorkflow)
-		workers[1].RegisterActivity(activities.ConcludeQueryOrder)
+		workers[1].RegisterWorkflow(workflows.Stale, workflow.VersioningBehaviorAutoUpgrade)
+		workers[1].RegisterActivity(orderActivities.QueryOrder)
+		workers[1].RegisterActivity(orderActivities.FinalizeStaleWorkflow)
+		workers[1].RegisterActivity(orderActivities.ConcludeQueryOrder)
+		workers[1].RegisterActivity(notifier.NotifyOrderResolution)
+		workers[1].RegisterActivity(pauseControl.PausedScope)
 
 		// Manual Handle Worker (index 2)
-		workers[2].RegisterWorkflow(workflows.ManualHandleOrder)
-		workers[2].RegisterActivity(activities.QueryOrder)
-		workers[2].RegisterActivity(activities.FinalizeStaleWorkflow)
-		workers[2].RegisterActivity(activities.ConcludeQueryOrder)
+		workers[2].RegisterWorkflow(workflows.ManualHandleOrder, workflow.VersioningBehaviorAutoUpgrade)
+		workers[2].RegisterActivity(orderActivities.QueryOrder)
+		workers[2].RegisterActivity(orderActivities.FinalizeStaleWorkflow)
+		workers[2].RegisterActivity(orderActivities.ConcludeQueryOrder)
+		workers[2].RegisterActivity(notifier.NotifyOrderResolution)
+		workers[2].RegisterActivity(activities.SendSLAAlert)
 
 		// Recurring Payment Worker (index 3)
-		workers[3].RegisterWorkflow(workflows.RegisterRecurringPayment)
+		workers[3].RegisterWorkflow(workflows.RegisterRecurringPayment, workflow.VersioningBehaviorPinned)
+		workers[3].RegisterWorkflow(workflows.RecurringPaymentDunning, workflow.VersioningBehaviorAutoUpgrade)
 		workers[3].RegisterActivity(activities.DoSomething)
-		workers[3].RegisterActivity(activities.RecurringPaymentV1)
-		workers[3].RegisterActivity(activities.RecurringPaymentV2)
-		workers[3].RegisterActivity(activities.RecurringPaymentV3)
+		workers[3].RegisterActivity(recurringActivities.RecurringPaymentV1)
+		workers[3].RegisterActivity(recurringActivities.RecurringPaymentV2)
+		workers[3].RegisterActivity(recurringActivities.RecurringPaymentV3)
+		workers[3].RegisterActivity(recurringActivities.ChargeRecurringPayment)
+		workers[3].RegisterActivity(recurringActivities.MarkContractDelinquent)
+		workers[3].RegisterActivity(notifier.NotifyCustomer)
 
 		var wg sync.WaitGroup
 		workerNames := []string{
@@ -100,4 +195,11 @@ var workerCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(workerCmd)
+
+	workerCmd.Flags().StringVar(&webhookConfigFile, "webhook-config", "", "JSON file with the webhook endpoints per business unit")
+	workerCmd.Flags().StringVar(&databaseDSN, "database", "orders.db", "Order database: a SQLite file path or a postgres:// URL")
+	workerCmd.Flags().StringVar(&rateLimitConfigFile, "rate-limit-config", "", "JSON file with the worker, upstream provider and business unit rate limits")
+	workerCmd.Flags().StringVar(&breakerConfigFile, "breaker-config", "", "JSON file with the circuit breaker policy per upstream provider")
+	workerCmd.Flags().StringVar(&buildID, "build-id", "", "Build ID of this worker; set it to run a versioned worker of --deployment-name")
+	workerCmd.Flags().StringVar(&deploymentName, "deployment-name", DefaultDeploymentName, "Worker deployment that the build belongs to")
 }
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"temporal-playground/internal/orders"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	workflowsOrderID       string
+	workflowsStage         string
+	workflowsEnvironment   string
+	workflowsBusinessUnit  string
+	workflowsStatus        string
+	workflowsStartedAfter  string
+	workflowsStartedBefore string
+	workflowsLimit         int
+	workflowsPageSize      int
+	workflowsPageToken     string
+	workflowsCount         bool
+	workflowsGroupBy       string
+)
+
+var listWorkflowsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List or count workflows by order, stage, environment, business unit, status or start time",
+	Long: `List the workflows of the namespace through visibility, or count them with --count.
+The environment is only indexed until an order escalates, so it matches query stage workflows and schedules.
+The business unit is kept in the workflow memo: it is matched on the listed pages and cannot be counted.
+When more workflows match than --limit, continue the listing with the printed --page-token.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := orders.WorkflowFilter{
+			OrderID:      workflowsOrderID,
+			Stage:        workflowsStage,
+			Environment:  workflowsEnvironment,
+			BusinessUnit: workflowsBusinessUnit,
+			Status:       workflowsStatus,
+		}
+		var err error
+		if filter.StartedAfter, err = parseOptionalTime(workflowsStartedAfter); err != nil {
+			log.Fatalf("--started-after must be an RFC3339 time: %v", err)
+		}
+		if filter.StartedBefore, err = parseOptionalTime(workflowsStartedBefore); err != nil {
+			log.Fatalf("--started-before must be an RFC3339 time: %v", err)
+		}
+		if workflowsGroupBy != "" && !workflowsCount {
+			log.Fatalf("--group-by needs --count")
+		}
+		nextPageToken, err := base64.StdEncoding.DecodeString(workflowsPageToken)
+		if err != nil {
+			log.Fatalf("Invalid --page-token: %v", err)
+		}
+
+		service, workflowManager := newOrderService()
+		defer workflowManager.Close()
+
+		if workflowsCount {
+			count, err := service.CountWorkflows(context.Background(), filter, workflowsGroupBy)
+			if err != nil {
+				log.Fatalf("Unable to count workflows: %v", err)
+			}
+			render(count, func() {
+				if len(count.Groups) > 0 {
+					fmt.Printf("%-24s %s\n", "GROUP", "COUNT")
+					for _, group := range count.Groups {
+						fmt.Printf("%-24s %d\n", group.Value, group.Count)
+					}
+					fmt.Println()
+				}
+				fmt.Printf("Total: %d workflows\n", count.Count)
+			})
+			return
+		}
+
+		result := orders.WorkflowPage{Workflows: []orders.OrderWorkflow{}}
+		for {
+			pageSize := workflowsPageSize
+			if workflowsLimit > 0 {
+				pageSize = min(pageSize, workflowsLimit-len(result.Workflows))
+			}
+			page, err := service.ListWorkflows(context.Background(), filter, pageSize, nextPageToken)
+			if err != nil {
+				log.Fatalf("Unable to list workflows: %v", err)
+			}
+			result.Workflows = append(result.Workflows, page.Workflows...)
+			nextPageToken = page.NextPageToken
+			if len(nextPageToken) == 0 || (workflowsLimit > 0 && len(result.Workflows) >= workflowsLimit) {
+				break
+			}
+		}
+		result.NextPageToken = nextPageToken
+
+		render(result, func() {
+			fmt.Printf("%-40s %-20s %-16s %-8s %-22s %s\n", "WORKFLOW", "TYPE", "STATUS", "STAGE", "STARTED AT", "CLOSED AT")
+			for _, workflow := range result.Workflows {
+				closedAt := "-"
+				if workflow.CloseTime != nil {
+					closedAt = workflow.CloseTime.Format(time.RFC3339)
+				}
+				stage := workflow.Stage
+				if stage == "" {
+					stage = "-"
+				}
+				fmt.Printf("%-40s %-20s %-16s %-8s %-22s %s\n", workflow.WorkflowID, workflow.WorkflowType, workflow.Status, stage,
+					workflow.StartTime.Format(time.RFC3339), closedAt)
+			}
+			fmt.Printf("\nTotal: %d workflows\n", len(result.Workflows))
+			if len(result.NextPageToken) > 0 {
+				fmt.Printf("More workflows match, continue with --page-token %s\n", base64.StdEncoding.EncodeToString(result.NextPageToken))
+			}
+		})
+	},
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func init() {
+	clientCmd.AddCommand(listWorkflowsCmd)
+
+	listWorkflowsCmd.Flags().StringVarP(&workflowsOrderID, "order-id", "o", "", "Only workflows of this order")
+	listWorkflowsCmd.Flags().StringVar(&workflowsStage, "stage", "", "Only workflows in this stage: query, stale or manual")
+	listWorkflowsCmd.Flags().StringVarP(&workflowsEnvironment, "environment", "e", "", "Only workflows started in this environment")
+	listWorkflowsCmd.Flags().StringVarP(&workflowsBusinessUnit, "business-unit", "b", "", "Only workflows of this business unit")
+	listWorkflowsCmd.Flags().StringVar(&workflowsStatus, "status", "", "Only workflows in this status, e.g. running, completed, failed or timed-out")
+	listWorkflowsCmd.Flags().StringVar(&workflowsStartedAfter, "started-after", "", "Only workflows started at or after this time (RFC3339)")
+	listWorkflowsCmd.Flags().StringVar(&workflowsStartedBefore, "started-before", "", "Only workflows started before this time (RFC3339)")
+	listWorkflowsCmd.Flags().IntVar(&workflowsLimit, "limit", 50, "Most workflows to list, 0 means all")
+	listWorkflowsCmd.Flags().IntVar(&workflowsPageSize, "page-size", 100, "Workflows read from visibility per request")
+	listWorkflowsCmd.Flags().StringVar(&workflowsPageToken, "page-token", "", "Continue a listing from the page token it printed")
+	listWorkflowsCmd.Flags().BoolVar(&workflowsCount, "count", false, "Count the matching workflows instead of listing them")
+	listWorkflowsCmd.Flags().StringVar(&workflowsGroupBy, "group-by", "", "Count per status, stage or type; needs --count")
+}
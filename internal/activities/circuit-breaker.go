@@ -0,0 +1,242 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"golang.org/x/sync/singleflight"
+)
+
+// breakerStateTTL bounds how often a worker queries the breaker workflow of a provider
+const breakerStateTTL = 2 * time.Second
+
+// ErrTypeCircuitOpen is the application error type of a call refused because the upstream's circuit breaker is open
+const ErrTypeCircuitOpen = "CircuitOpen"
+
+var DefaultBreakerPolicy = models.BreakerPolicy{
+	FailureThreshold:  5,
+	OpenDuration:      30 * time.Second,
+	HalfOpenSuccesses: 1,
+}
+
+// BreakerClient reads and updates the shared circuit breaker state of the upstream providers
+type BreakerClient interface {
+	State(ctx context.Context, provider string) (models.BreakerState, error)
+	Report(ctx context.Context, provider string, report models.BreakerReport) error
+}
+
+// BreakerPolicyConfig is the JSON form of a breaker policy, with the open duration as a Go duration string
+type BreakerPolicyConfig struct {
+	FailureThreshold  int    `json:"failureThreshold"`
+	OpenDuration      string `json:"openDuration"`
+	HalfOpenSuccesses int    `json:"halfOpenSuccesses,omitempty"`
+}
+
+// BreakerConfig holds the breaker policy per upstream provider; Default is used for providers without one
+type BreakerConfig struct {
+	Default   models.BreakerPolicy
+	Providers map[string]models.BreakerPolicy
+}
+
+// LoadBreakerConfig reads the breaker policies from a JSON file
+func LoadBreakerConfig(path string) (BreakerConfig, error) {
+	config := BreakerConfig{
+		Default:   DefaultBreakerPolicy,
+		Providers: map[string]models.BreakerPolicy{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read breaker config '%s': %w", path, err)
+	}
+
+	var file struct {
+		Default   *BreakerPolicyConfig           `json:"default,omitempty"`
+		Providers map[string]BreakerPolicyConfig `json:"providers,omitempty"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return config, fmt.Errorf("failed to parse breaker config '%s': %w", path, err)
+	}
+
+	if file.Default != nil {
+		if config.Default, err = file.Default.policy(); err != nil {
+			return config, fmt.Errorf("invalid default breaker policy: %w", err)
+		}
+	}
+	for provider, policyConfig := range file.Providers {
+		if config.Providers[provider], err = policyConfig.policy(); err != nil {
+			return config, fmt.Errorf("invalid breaker policy for provider %s: %w", provider, err)
+		}
+	}
+	return config, nil
+}
+
+func (pc BreakerPolicyConfig) policy() (models.BreakerPolicy, error) {
+	policy := models.BreakerPolicy{
+		FailureThreshold:  pc.FailureThreshold,
+		OpenDuration:      DefaultBreakerPolicy.OpenDuration,
+		HalfOpenSuccesses: max(pc.HalfOpenSuccesses, 1),
+	}
+	if pc.OpenDuration != "" {
+		var err error
+		if policy.OpenDuration, err = time.ParseDuration(pc.OpenDuration); err != nil {
+			return policy, err
+		}
+	}
+	if policy.FailureThreshold <= 0 || policy.OpenDuration <= 0 {
+		return policy, fmt.Errorf("failureThreshold and openDuration must be positive")
+	}
+	return policy, nil
+}
+
+// Policy returns the breaker policy of the provider
+func (bc BreakerConfig) Policy(provider string) models.BreakerPolicy {
+	if policy, ok := bc.Providers[provider]; ok {
+		return policy
+	}
+	if bc.Default.FailureThreshold > 0 {
+		return bc.Default
+	}
+	return DefaultBreakerPolicy
+}
+
+// Breakers guards upstream calls with the circuit breakers. A nil Breakers lets every call through.
+// The breakers fail open: when their state cannot be read, calls go through as if they were closed.
+// Each worker caches the breaker states briefly and only reports the outcomes that can change a breaker,
+// so a burst of orders does not flood the breaker workflows with queries and signals
+type Breakers struct {
+	client BreakerClient
+	config BreakerConfig
+
+	fetches   singleflight.Group // one state query per provider at a time, shared by the calls waiting for it
+	mu        sync.Mutex         // guards providers; never held while querying a breaker workflow
+	providers map[string]*providerBreaker
+}
+
+// providerBreaker is the worker's view of the breaker of one provider
+type providerBreaker struct {
+	state     models.BreakerState
+	fetchedAt time.Time
+	probes    int  // calls let through to probe a half-open breaker since its state was fetched
+	failing   bool // the last outcome reported was a failure
+}
+
+func NewBreakers(client BreakerClient, config BreakerConfig) *Breakers {
+	return &Breakers{
+		client:    client,
+		config:    config,
+		providers: map[string]*providerBreaker{},
+	}
+}
+
+// Allow returns a non-retryable CircuitOpen error carrying the breaker state when the provider's breaker is open,
+// so the activity does not use up its attempts and the workflow can wait for the breaker instead.
+// A half-open breaker lets through as many probe calls per worker as it needs successes to close
+func (b *Breakers) Allow(ctx context.Context, provider string) error {
+	if b == nil {
+		return nil
+	}
+
+	breaker, err := b.refresh(ctx, provider)
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Unable to read circuit breaker state - letting the call through", "provider", provider, "error", err.Error())
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := breaker.state
+	switch state.State {
+	case models.BreakerOpen:
+		return circuitOpenError(fmt.Sprintf("circuit breaker for provider %s is open: %s", provider, state.LastError), state)
+	case models.BreakerHalfOpen:
+		if breaker.probes >= max(state.Policy.HalfOpenSuccesses, 1) {
+			return circuitOpenError(fmt.Sprintf("circuit breaker for provider %s is half-open and already probing", provider), state)
+		}
+		breaker.probes++
+	}
+	return nil
+}
+
+// Record reports the outcome of an upstream call to the provider's breaker. Failures are always reported;
+// successes only when they can change the breaker: after a failure, or while it is not closed
+func (b *Breakers) Record(ctx context.Context, provider string, upstreamErr string) {
+	if b == nil {
+		return
+	}
+
+	success := upstreamErr == ""
+	b.mu.Lock()
+	breaker := b.cached(provider)
+	report := !success || breaker.failing || breaker.state.State != models.BreakerClosed || breaker.state.ConsecutiveFailures > 0
+	breaker.failing = !success
+	if success && breaker.state.State == models.BreakerClosed {
+		// the reported success resets the count, so the next ones need not be reported until the state is fetched again
+		breaker.state.ConsecutiveFailures = 0
+	}
+	b.mu.Unlock()
+
+	if !report {
+		return
+	}
+	if err := b.client.Report(ctx, provider, models.BreakerReport{
+		Success: success,
+		Error:   upstreamErr,
+		Policy:  b.config.Policy(provider),
+	}); err != nil {
+		activity.GetLogger(ctx).Warn("Unable to report to circuit breaker", "provider", provider, "error", err.Error())
+	}
+}
+
+// cached returns the worker's view of the provider's breaker. It must be called with the mutex held
+func (b *Breakers) cached(provider string) *providerBreaker {
+	breaker, ok := b.providers[provider]
+	if !ok {
+		breaker = &providerBreaker{}
+		b.providers[provider] = breaker
+	}
+	return breaker
+}
+
+// refresh returns the worker's view of the provider's breaker, fetching its state when older than breakerStateTTL.
+// Concurrent calls share one query, which runs without the mutex so a slow breaker workflow only delays the calls
+// to its own provider
+func (b *Breakers) refresh(ctx context.Context, provider string) (*providerBreaker, error) {
+	b.mu.Lock()
+	breaker := b.cached(provider)
+	fresh := time.Since(breaker.fetchedAt) < breakerStateTTL
+	b.mu.Unlock()
+	if fresh {
+		return breaker, nil
+	}
+
+	_, err, _ := b.fetches.Do(provider, func() (any, error) {
+		state, err := b.client.State(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		b.mu.Lock()
+		breaker.state, breaker.fetchedAt, breaker.probes = state, time.Now(), 0
+		b.mu.Unlock()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return breaker, nil
+}
+
+func circuitOpenError(message string, state models.BreakerState) error {
+	return temporal.NewApplicationErrorWithOptions(message, ErrTypeCircuitOpen, temporal.ApplicationErrorOptions{
+		NonRetryable: true,
+		Details:      []any{state},
+	})
+}
@@ -8,15 +8,36 @@ import (
 	"go.temporal.io/sdk/activity"
 )
 
-// ConcludeQueryOrder concludes an order with manual resolution
-func ConcludeQueryOrder(ctx context.Context, request models.ConcludeQueryOrderRequest) error {
+// ConcludeQueryOrder concludes an order and records its final state
+func (oa *OrderActivities) ConcludeQueryOrder(ctx context.Context, request models.ConcludeQueryOrderRequest) error {
 	logger := activity.GetLogger(ctx)
 
-	logger.Info("Concluding query order with manual resolution",
+	logger.Info("Concluding query order",
 		"orderID", request.OrderID,
 		"resolution", request.Resolution,
 		"resolvedBy", request.ResolvedBy,
-		"resolvedAt", request.ResolvedAt.Format(time.RFC3339))
+		"resolvedAt", request.ResolvedAt.Format(time.RFC3339),
+		"auditEntries", len(request.AuditTrail))
 
-	return nil
+	for _, entry := range request.AuditTrail {
+		logger.Info("Audit trail entry",
+			"orderID", request.OrderID,
+			"time", entry.Time.Format(time.RFC3339),
+			"action", entry.Action,
+			"operator", entry.Operator,
+			"detail", entry.Detail,
+			"rejected", entry.Rejected)
+	}
+
+	return oa.saveOrder(ctx, models.OrderRecord{
+		OrderID:        request.OrderID,
+		Resolution:     request.Resolution,
+		ResolvedBy:     request.ResolvedBy,
+		BusinessUnit:   request.BusinessUnit,
+		Priority:       request.Priority,
+		StartedAt:      request.StartedAt,
+		ResolvedAt:     request.ResolvedAt,
+		ErrorHistory:   request.ErrorHistory,
+		EscalationPath: request.EscalationPath,
+	})
 }
@@ -0,0 +1,167 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/repository"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// WebhookEndpoint is a payment service endpoint that receives order resolution events
+type WebhookEndpoint struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"` // HMAC-SHA256 key used to sign the request body
+}
+
+// WebhookConfig maps business units to their webhook endpoints; Default is used for business units without endpoints
+type WebhookConfig struct {
+	BusinessUnits map[string][]WebhookEndpoint `json:"businessUnits"`
+	Default       []WebhookEndpoint            `json:"default,omitempty"`
+}
+
+// LoadWebhookConfig reads the webhook endpoints from a JSON file
+func LoadWebhookConfig(path string) (WebhookConfig, error) {
+	var config WebhookConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read webhook config '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse webhook config '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// Notifier delivers order resolution events to the webhook endpoints of the order's business unit
+type Notifier struct {
+	config      WebhookConfig
+	deliveryLog repository.DeliveryLog
+	httpClient  *http.Client
+}
+
+func NewNotifier(config WebhookConfig, deliveryLog repository.DeliveryLog) *Notifier {
+	return &Notifier{
+		config:      config,
+		deliveryLog: deliveryLog,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NotifyOrderResolution POSTs a signed event to every endpoint of the business unit.
+// Endpoints that already accepted the event are kept in the heartbeat details, so a retry
+// only delivers to the endpoints that failed
+func (n *Notifier) NotifyOrderResolution(ctx context.Context, event models.OrderResolutionEvent) error {
+	return n.deliver(ctx, event.EventID, event.OrderID, event.Resolution, event.BusinessUnit, event)
+}
+
+// NotifyCustomer POSTs a signed dunning notice to every endpoint of the contract's business unit,
+// whose payment service tells the customer about the failed payment
+func (n *Notifier) NotifyCustomer(ctx context.Context, notice models.DunningNotice) error {
+	return n.deliver(ctx, notice.NoticeID, notice.ConsentID, "dunning-"+notice.Stage, notice.BusinessUnit, notice)
+}
+
+// deliver POSTs the event to the endpoints of the business unit, recording each attempt in the delivery log
+func (n *Notifier) deliver(ctx context.Context, eventID string, orderID string, kind string, businessUnit string, event any) error {
+	logger := activity.GetLogger(ctx)
+	info := activity.GetInfo(ctx)
+
+	endpoints, ok := n.config.BusinessUnits[businessUnit]
+	if !ok {
+		endpoints = n.config.Default
+	}
+	if len(endpoints) == 0 {
+		logger.Info("No webhook endpoints configured", "businessUnit", businessUnit, "orderID", orderID)
+		return nil
+	}
+
+	delivered := map[string]bool{}
+	if activity.HasHeartbeatDetails(ctx) {
+		_ = activity.GetHeartbeatDetails(ctx, &delivered)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, endpoint := range endpoints {
+		if delivered[endpoint.URL] {
+			continue
+		}
+
+		delivery := models.WebhookDelivery{
+			EventID:      eventID,
+			OrderID:      orderID,
+			Resolution:   kind,
+			BusinessUnit: businessUnit,
+			Endpoint:     endpoint.URL,
+			Attempt:      info.Attempt,
+			DeliveredAt:  time.Now(),
+		}
+		delivery.StatusCode, err = n.post(ctx, endpoint, eventID, body)
+		if err != nil {
+			failed++
+			delivery.Error = err.Error()
+			logger.Warn("Webhook delivery failed", "endpoint", endpoint.URL, "orderID", orderID, "attempt", info.Attempt, "error", err.Error())
+		} else {
+			delivered[endpoint.URL] = true
+			logger.Info("Webhook delivered", "endpoint", endpoint.URL, "orderID", orderID, "statusCode", delivery.StatusCode)
+		}
+
+		if err := n.deliveryLog.RecordDelivery(ctx, delivery); err != nil {
+			logger.Error("Failed to record webhook delivery", "error", err.Error())
+		}
+		activity.RecordHeartbeat(ctx, delivered)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("webhook delivery failed for %d of %d endpoints", failed, len(endpoints))
+	}
+	return nil
+}
+
+func (n *Notifier) post(ctx context.Context, endpoint WebhookEndpoint, eventID string, body []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-ID", eventID)
+	request.Header.Set("X-Signature-Timestamp", timestamp)
+	request.Header.Set("X-Signature", "sha256="+Sign(endpoint.Secret, timestamp, body))
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("endpoint returned %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>", which receivers recompute to verify a webhook
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
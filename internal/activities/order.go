@@ -0,0 +1,53 @@
+package activities
+
+import (
+	"context"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/repository"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// OrderActivities query orders within the upstream rate limits and circuit breakers, conclude them
+// and record their final state in the order repository
+type OrderActivities struct {
+	repository  repository.OrderRepository
+	rateLimiter *RateLimiter
+	breakers    *Breakers
+}
+
+func NewOrderActivities(orderRepository repository.OrderRepository, rateLimiter *RateLimiter, breakers *Breakers) *OrderActivities {
+	return &OrderActivities{
+		repository:  orderRepository,
+		rateLimiter: rateLimiter,
+		breakers:    breakers,
+	}
+}
+
+// provider returns the upstream provider that serves the business unit
+func (oa *OrderActivities) provider(businessUnit string) string {
+	if provider := oa.rateLimiter.Provider(businessUnit); provider != "" {
+		return provider
+	}
+	return "default"
+}
+
+// saveOrder writes the record keyed by the calling workflow run, so retries of the activity overwrite it
+func (oa *OrderActivities) saveOrder(ctx context.Context, record models.OrderRecord) error {
+	if oa.repository == nil {
+		return nil
+	}
+
+	info := activity.GetInfo(ctx)
+	record.WorkflowID = info.WorkflowExecution.ID
+	record.RunID = info.WorkflowExecution.RunID
+	record.RecordedAt = time.Now()
+
+	if err := oa.repository.SaveOrder(ctx, record); err != nil {
+		return err
+	}
+
+	activity.GetLogger(ctx).Info("Order recorded", "orderID", record.OrderID, "resolution", record.Resolution, "workflowID", record.WorkflowID)
+	return nil
+}
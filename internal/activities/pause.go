@@ -0,0 +1,65 @@
+package activities
+
+import (
+	"context"
+	"sync"
+	"temporal-playground/internal/models"
+	"time"
+)
+
+// pauseStateTTL bounds how often a worker queries the processing control workflow
+const pauseStateTTL = 2 * time.Second
+
+// PauseClient reads the paused business units and environments
+type PauseClient interface {
+	PausedScopes(ctx context.Context) ([]models.PausedScope, error)
+}
+
+// PauseControl tells order workflows whether their business unit or environment is paused.
+// The paused scopes are cached briefly so a burst of orders does not flood the control workflow with queries
+type PauseControl struct {
+	client PauseClient
+
+	mu        sync.Mutex
+	paused    []models.PausedScope
+	fetchedAt time.Time
+}
+
+func NewPauseControl(client PauseClient) *PauseControl {
+	return &PauseControl{
+		client: client,
+	}
+}
+
+// PausedScope returns the paused scope that holds an order of the business unit and environment, or nil.
+// It runs as a local activity
+func (pc *PauseControl) PausedScope(ctx context.Context, businessUnit string, environment string) (*models.PausedScope, error) {
+	paused, err := pc.pausedScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range paused {
+		if (scope.Scope == models.PauseScopeBusinessUnit && scope.Value == businessUnit && businessUnit != "") ||
+			(scope.Scope == models.PauseScopeEnvironment && scope.Value == environment && environment != "") {
+			return &scope, nil
+		}
+	}
+	return nil, nil
+}
+
+func (pc *PauseControl) pausedScopes(ctx context.Context) ([]models.PausedScope, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if time.Since(pc.fetchedAt) < pauseStateTTL {
+		return pc.paused, nil
+	}
+
+	paused, err := pc.client.PausedScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pc.paused, pc.fetchedAt = paused, time.Now()
+	return paused, nil
+}
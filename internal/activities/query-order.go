@@ -3,6 +3,7 @@ package activities
 import (
 	"context"
 	"math/rand/v2"
+	"strconv"
 	"temporal-playground/internal/errors"
 	"time"
 
@@ -12,13 +13,25 @@ import (
 
 const (
 	FailProbability = 0.8 // 80% failed
+	MaxRetryAfter   = 30  // seconds, upper bound of the simulated Retry-After header
 )
 
 // heavy operation that will fail randomly
-// always retry if failed
-func QueryOrder(ctx context.Context, orderID string) error {
+// always retry if failed, after the Retry-After delay when the upstream provider throttled the call.
+// A throttled call still uses up one of the MaximumAttempts of the caller's retry policy, so a long
+// throttling period can move the order to the stale stage like any other failure
+func (oa *OrderActivities) QueryOrder(ctx context.Context, orderID string, businessUnit string) error {
 
 	info := activity.GetInfo(ctx)
+	provider := oa.provider(businessUnit)
+
+	// Fail fast while the upstream is down, then wait for its token bucket before calling it
+	if err := oa.breakers.Allow(ctx, provider); err != nil {
+		return err
+	}
+	if err := oa.rateLimiter.Wait(ctx, businessUnit); err != nil {
+		return err
+	}
 
 	// Record heartbeat with detailed progress info
 	progressInfo := map[string]any{
@@ -55,6 +68,22 @@ func QueryOrder(ctx context.Context, orderID string) error {
 		progressInfo["error"] = errorType
 		activity.RecordHeartbeat(ctx, progressInfo)
 
+		if errors.IsUpstreamFailure(errorType) {
+			oa.breakers.Record(ctx, provider, errorType)
+		}
+
+		if errorType == errors.UpstreamThrottled {
+			// simulated 429 response; retry no sooner than the provider asked for
+			retryAfter := ParseRetryAfter(strconv.Itoa(1+rand.IntN(MaxRetryAfter)), time.Now())
+			errorDetails["provider"] = provider
+			errorDetails["retryAfter"] = retryAfter.String()
+
+			return temporal.NewApplicationErrorWithOptions(errorType, "UpstreamThrottled", temporal.ApplicationErrorOptions{
+				NextRetryDelay: retryAfter,
+				Details:        []any{errorDetails},
+			})
+		}
+
 		return temporal.NewApplicationError(
 			errorType,
 			"QueryOrderActivity",
@@ -62,6 +91,8 @@ func QueryOrder(ctx context.Context, orderID string) error {
 		)
 	}
 
+	oa.breakers.Record(ctx, provider, "")
+
 	progressInfo["step"] = "completed"
 	progressInfo["result"] = "success"
 	activity.RecordHeartbeat(ctx, progressInfo)
@@ -0,0 +1,185 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"golang.org/x/time/rate"
+)
+
+const rateLimitHeartbeatInterval = 5 * time.Second
+
+// RateLimit is a token bucket: PerSecond tokens are added every second, up to Burst
+type RateLimit struct {
+	PerSecond float64 `json:"perSecond"`
+	Burst     int     `json:"burst,omitempty"` // defaults to 1
+}
+
+// WorkerRateLimit caps how many activities a task queue starts per second, across all workers and per worker
+type WorkerRateLimit struct {
+	TaskQueueActivitiesPerSecond float64 `json:"taskQueueActivitiesPerSecond,omitempty"`
+	WorkerActivitiesPerSecond    float64 `json:"workerActivitiesPerSecond,omitempty"`
+}
+
+// BusinessUnitRateLimit routes a business unit to its upstream provider, optionally capping its own share of that provider
+type BusinessUnitRateLimit struct {
+	Provider string `json:"provider"`
+	RateLimit
+}
+
+// RateLimitConfig configures the rate limits that protect the upstream wallets and banks
+type RateLimitConfig struct {
+	Workers         map[string]WorkerRateLimit       `json:"workers,omitempty"` // keyed by task queue
+	Providers       map[string]RateLimit             `json:"providers,omitempty"`
+	BusinessUnits   map[string]BusinessUnitRateLimit `json:"businessUnits,omitempty"`
+	DefaultProvider string                           `json:"defaultProvider,omitempty"` // for business units without a provider
+}
+
+// LoadRateLimitConfig reads and validates the rate limits from a JSON file
+func LoadRateLimitConfig(path string) (RateLimitConfig, error) {
+	var config RateLimitConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read rate limit config '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse rate limit config '%s': %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return config, fmt.Errorf("invalid rate limit config '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate rejects limits a token bucket cannot serve: a provider must allow a positive rate, and a business unit
+// a positive rate or 0 for no cap of its own. A bucket with a zero rate would refuse every call after its burst
+func (c RateLimitConfig) Validate() error {
+	for taskQueue, limit := range c.Workers {
+		if limit.TaskQueueActivitiesPerSecond < 0 || limit.WorkerActivitiesPerSecond < 0 {
+			return fmt.Errorf("worker rate limit of task queue '%s' must not be negative", taskQueue)
+		}
+	}
+	for provider, limit := range c.Providers {
+		if limit.PerSecond <= 0 {
+			return fmt.Errorf("provider '%s' must allow a positive perSecond, got %g", provider, limit.PerSecond)
+		}
+		if limit.Burst < 0 {
+			return fmt.Errorf("burst of provider '%s' must not be negative", provider)
+		}
+	}
+	for businessUnit, limit := range c.BusinessUnits {
+		if limit.PerSecond < 0 {
+			return fmt.Errorf("business unit '%s' must not have a negative perSecond, use 0 for no cap of its own", businessUnit)
+		}
+		if limit.Burst < 0 {
+			return fmt.Errorf("burst of business unit '%s' must not be negative", businessUnit)
+		}
+	}
+	return nil
+}
+
+// RateLimiter holds one token bucket per upstream provider, plus one per business unit that caps its own share.
+// A nil RateLimiter does not limit
+type RateLimiter struct {
+	config RateLimitConfig
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		config:  config,
+		buckets: map[string]*rate.Limiter{},
+	}
+}
+
+// Provider returns the upstream provider that serves the business unit
+func (rl *RateLimiter) Provider(businessUnit string) string {
+	if rl == nil {
+		return ""
+	}
+	if limit, ok := rl.config.BusinessUnits[businessUnit]; ok && limit.Provider != "" {
+		return limit.Provider
+	}
+	return rl.config.DefaultProvider
+}
+
+// Wait blocks until the provider of the business unit, and the business unit itself, allow another call.
+// It heartbeats while waiting so a long wait does not time out the activity
+func (rl *RateLimiter) Wait(ctx context.Context, businessUnit string) error {
+	if rl == nil {
+		return nil
+	}
+
+	provider := rl.Provider(businessUnit)
+	if limit, ok := rl.config.Providers[provider]; ok {
+		if err := rl.wait(ctx, "provider/"+provider, limit); err != nil {
+			return err
+		}
+	}
+	if limit, ok := rl.config.BusinessUnits[businessUnit]; ok && limit.PerSecond > 0 {
+		if err := rl.wait(ctx, "business-unit/"+businessUnit, limit.RateLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rl *RateLimiter) wait(ctx context.Context, key string, limit RateLimit) error {
+	reservation := rl.bucket(key, limit).Reserve()
+	if !reservation.OK() {
+		return fmt.Errorf("rate limit %s can never allow a call", key)
+	}
+
+	delay := reservation.Delay()
+	if delay > 0 {
+		activity.GetLogger(ctx).Info("Waiting for upstream rate limit", "bucket", key, "delay", delay.String())
+	}
+	for delay > 0 {
+		select {
+		case <-time.After(min(delay, rateLimitHeartbeatInterval)):
+		case <-ctx.Done():
+			reservation.Cancel()
+			return ctx.Err()
+		}
+		activity.RecordHeartbeat(ctx, map[string]any{"step": "rate-limited", "bucket": key})
+		delay = reservation.Delay()
+	}
+	return nil
+}
+
+func (rl *RateLimiter) bucket(key string, limit RateLimit) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(limit.PerSecond), max(limit.Burst, 1))
+		rl.buckets[key] = bucket
+	}
+	return bucket
+}
+
+// ParseRetryAfter reads a Retry-After header, given either in seconds or as an HTTP date.
+// It returns zero when the header is missing or invalid
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
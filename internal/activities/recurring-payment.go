@@ -2,34 +2,184 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"sync"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/repository"
 	"time"
 
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 )
 
-func RecurringPaymentV1(ctx context.Context, consentID string) (string, error) {
-	return processPayment(ctx, consentID, 10.00)
+// ErrTypeInvalidContract is the application error type of a recurring payment contract that cannot be charged
+const ErrTypeInvalidContract = "InvalidContract"
+
+// RecurringPaymentClient changes the schedules of recurring payments
+type RecurringPaymentClient interface {
+	MarkRecurringPaymentDelinquent(ctx context.Context, consentID string, reason string) error
+}
+
+// RecurringPaymentActivities charge the terms of recurring payments, at most once per term, and change
+// their schedules from the payment workflows
+type RecurringPaymentActivities struct {
+	client RecurringPaymentClient
+	ledger repository.ChargeLedger
+	pay    func(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error)
+}
+
+func NewRecurringPaymentActivities(client RecurringPaymentClient, ledger repository.ChargeLedger) *RecurringPaymentActivities {
+	return &RecurringPaymentActivities{
+		client: client,
+		ledger: ledger,
+		pay:    newPaymentProvider(processPayment).pay,
+	}
+}
+
+func (ra *RecurringPaymentActivities) RecurringPaymentV1(ctx context.Context, consentID string, term models.ChargeTerm) (string, error) {
+	return ra.charge(ctx, consentID, models.Money{Minor: 1000, Currency: "USD"}, term)
+}
+
+func (ra *RecurringPaymentActivities) RecurringPaymentV2(ctx context.Context, consentID string, term models.ChargeTerm) (string, error) {
+	return ra.charge(ctx, consentID, models.Money{Minor: 1500, Currency: "USD"}, term)
+}
+
+func (ra *RecurringPaymentActivities) RecurringPaymentV3(ctx context.Context, consentID string, term models.ChargeTerm) (string, error) {
+	return ra.charge(ctx, consentID, models.Money{Minor: 2000, Currency: "USD"}, term)
+}
+
+// ChargeRecurringPayment charges the amount of the recurring payment contract for the term
+func (ra *RecurringPaymentActivities) ChargeRecurringPayment(ctx context.Context, contract models.RecurringPaymentContract, term models.ChargeTerm) (string, error) {
+	if err := contract.Amount.Validate(); err != nil {
+		return "", temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("recurring payment %s cannot be charged: %v", contract.ConsentID, err), ErrTypeInvalidContract, nil)
+	}
+	return ra.charge(ctx, contract.ConsentID, contract.Amount, term)
+}
+
+// charge takes the payment unless the ledger already holds a charge for the term, e.g. when an earlier attempt
+// charged the customer but failed before completing. The idempotency key is also sent to the payment provider,
+// which deduplicates attempts that charged without reaching the ledger
+func (ra *RecurringPaymentActivities) charge(ctx context.Context, consentID string, amount models.Money, term models.ChargeTerm) (string, error) {
+	logger := activity.GetLogger(ctx)
+	if term.IdempotencyKey == "" || ra.ledger == nil {
+		// payment workflows started before charges carried a term
+		logger.Warn("Charging without an idempotency key", "consentID", consentID)
+		return ra.pay(ctx, consentID, amount, "")
+	}
+
+	charged, err := ra.ledger.GetCharge(ctx, term.IdempotencyKey)
+	if err == nil {
+		logger.Info("Term already charged - skipping", "consentID", consentID, "idempotencyKey", term.IdempotencyKey, "chargedAt", charged.ChargedAt)
+		return charged.Result, nil
+	}
+	if !errors.Is(err, repository.ErrChargeNotFound) {
+		return "", err
+	}
+
+	result, err := ra.pay(ctx, consentID, amount, term.IdempotencyKey)
+	if err != nil {
+		return "", err
+	}
+
+	info := activity.GetInfo(ctx)
+	err = ra.ledger.RecordCharge(ctx, models.ChargeRecord{
+		IdempotencyKey: term.IdempotencyKey,
+		ConsentID:      consentID,
+		Amount:         amount,
+		ScheduledAt:    term.ScheduledAt,
+		WorkflowID:     info.WorkflowExecution.ID,
+		RunID:          info.WorkflowExecution.RunID,
+		Attempt:        info.Attempt,
+		Result:         result,
+		ChargedAt:      time.Now(),
+	})
+	if errors.Is(err, repository.ErrDuplicateCharge) {
+		// a concurrent attempt recorded the term first; the provider charged it once for both
+		charged, err := ra.ledger.GetCharge(ctx, term.IdempotencyKey)
+		if err != nil {
+			return "", err
+		}
+		return charged.Result, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// paymentProvider stands in for the payment provider's deduplication: a payment whose idempotency key was
+// already charged, or is being charged by a concurrent attempt, returns the result of that charge instead of
+// charging the customer again. Failed payments are forgotten so they can be retried
+type paymentProvider struct {
+	mu       sync.Mutex
+	payments map[string]*providerPayment
+	process  func(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error)
 }
 
-func RecurringPaymentV2(ctx context.Context, consentID string) (string, error) {
-	return processPayment(ctx, consentID, 15.00)
+type providerPayment struct {
+	done   chan struct{}
+	result string
+	err    error
 }
 
-func RecurringPaymentV3(ctx context.Context, consentID string) (string, error) {
-	return processPayment(ctx, consentID, 20.00)
+func newPaymentProvider(process func(context.Context, string, models.Money, string) (string, error)) *paymentProvider {
+	return &paymentProvider{payments: map[string]*providerPayment{}, process: process}
 }
 
-func processPayment(ctx context.Context, consentID string, amount float64) (string, error) {
+func (pp *paymentProvider) pay(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error) {
+	if idempotencyKey == "" {
+		return pp.process(ctx, consentID, amount, idempotencyKey)
+	}
+
+	for {
+		pp.mu.Lock()
+		payment, ok := pp.payments[idempotencyKey]
+		if !ok {
+			payment = &providerPayment{done: make(chan struct{})}
+			pp.payments[idempotencyKey] = payment
+			pp.mu.Unlock()
+
+			payment.result, payment.err = pp.process(ctx, consentID, amount, idempotencyKey)
+			if payment.err != nil {
+				pp.mu.Lock()
+				delete(pp.payments, idempotencyKey)
+				pp.mu.Unlock()
+			}
+			close(payment.done)
+			return payment.result, payment.err
+		}
+		pp.mu.Unlock()
+
+		select {
+		case <-payment.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if payment.err == nil {
+			return payment.result, nil
+		}
+		// the other attempt failed without charging, so charge again
+	}
+}
+
+func processPayment(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error) {
 	logger := activity.GetLogger(ctx)
 
 	time.Sleep(time.Second * 30)
 
-	logger.Info("Processing payment of ", "amount", amount, "consentID", consentID)
+	logger.Info("Processing payment of ", "amount", amount.String(), "consentID", consentID, "idempotencyKey", idempotencyKey)
 
 	if rand.Float64() < 1 {
-		return fmt.Sprintf("Payment of $%.2f processed successfully", amount), nil
+		return fmt.Sprintf("Payment of %s processed successfully", amount), nil
 	}
 	return "", fmt.Errorf("payment processing failed")
 }
+
+// MarkContractDelinquent pauses the schedule of a recurring payment whose dunning failed and marks its contract delinquent
+func (ra *RecurringPaymentActivities) MarkContractDelinquent(ctx context.Context, consentID string, reason string) error {
+	activity.GetLogger(ctx).Warn("Marking recurring payment delinquent", "consentID", consentID, "reason", reason)
+	return ra.client.MarkRecurringPaymentDelinquent(ctx, consentID, reason)
+}
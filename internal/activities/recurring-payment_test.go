@@ -0,0 +1,206 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/repository"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+)
+
+// memoryLedger is a ChargeLedger kept in memory. failNextRecord makes the next RecordCharge store the charge
+// and still fail, like a database connection lost after the commit
+type memoryLedger struct {
+	mu             sync.Mutex
+	charges        map[string]models.ChargeRecord
+	failNextRecord bool
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{charges: map[string]models.ChargeRecord{}}
+}
+
+func (ml *memoryLedger) RecordCharge(ctx context.Context, record models.ChargeRecord) error {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	if _, ok := ml.charges[record.IdempotencyKey]; ok {
+		return fmt.Errorf("%w: %s", repository.ErrDuplicateCharge, record.IdempotencyKey)
+	}
+	ml.charges[record.IdempotencyKey] = record
+	if ml.failNextRecord {
+		ml.failNextRecord = false
+		return errors.New("connection reset after commit")
+	}
+	return nil
+}
+
+func (ml *memoryLedger) GetCharge(ctx context.Context, idempotencyKey string) (models.ChargeRecord, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	record, ok := ml.charges[idempotencyKey]
+	if !ok {
+		return models.ChargeRecord{}, repository.ErrChargeNotFound
+	}
+	return record, nil
+}
+
+func (ml *memoryLedger) ListCharges(ctx context.Context, consentID string) ([]models.ChargeRecord, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	var records []models.ChargeRecord
+	for _, record := range ml.charges {
+		if record.ConsentID == consentID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+var (
+	testContract = models.RecurringPaymentContract{
+		ConsentID: "consent-123",
+		Amount:    models.Money{Minor: 4990, Currency: "MYR"},
+	}
+	testTerm = models.ChargeTerm{
+		IdempotencyKey: "consent-123/2025-01-01T00:00:00Z",
+		ScheduledAt:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+// countingPayments stands in for the payment provider, counting the payments it takes
+func countingPayments(payments *int) func(context.Context, string, models.Money, string) (string, error) {
+	return func(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error) {
+		*payments++
+		return fmt.Sprintf("payment %d of %s", *payments, amount), nil
+	}
+}
+
+func TestChargeRetryAfterPartialFailureChargesOnce(t *testing.T) {
+	var (
+		testSuite testsuite.WorkflowTestSuite
+		ledger    = newMemoryLedger()
+		payments  int
+	)
+	recurringActivities := NewRecurringPaymentActivities(nil, ledger)
+	recurringActivities.pay = countingPayments(&payments)
+
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(recurringActivities)
+
+	// the first attempt charges the customer and records the charge, but fails before it completes
+	ledger.failNextRecord = true
+	if _, err := env.ExecuteActivity(recurringActivities.ChargeRecurringPayment, testContract, testTerm); err == nil {
+		t.Fatal("first attempt succeeded, want the ledger failure")
+	}
+
+	// the retry finds the recorded charge and returns its result without charging again
+	value, err := env.ExecuteActivity(recurringActivities.ChargeRecurringPayment, testContract, testTerm)
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	var result string
+	if err := value.Get(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if payments != 1 {
+		t.Errorf("customer charged %d times, want 1", payments)
+	}
+	if want := "payment 1 of MYR 49.90"; result != want {
+		t.Errorf("retry returned %q, want the recorded %q", result, want)
+	}
+	if records, _ := ledger.ListCharges(context.Background(), testContract.ConsentID); len(records) != 1 {
+		t.Errorf("ledger holds %d charges, want 1", len(records))
+	}
+}
+
+func TestChargeRacingAttemptsChargeOnce(t *testing.T) {
+	var (
+		ledger   = newMemoryLedger()
+		payments atomic.Int32
+	)
+	recurringActivities := NewRecurringPaymentActivities(nil, ledger)
+	recurringActivities.pay = newPaymentProvider(func(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error) {
+		time.Sleep(50 * time.Millisecond) // keep the payment in flight while the other attempt reaches the provider
+		return fmt.Sprintf("payment %d of %s", payments.Add(1), amount), nil
+	}).pay
+
+	// two attempts of the same term race, e.g. a retry started while the timed out attempt is still running;
+	// however they interleave, the provider charges once and both return that charge
+	var (
+		wg      sync.WaitGroup
+		results [2]string
+		errs    [2]error
+	)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var testSuite testsuite.WorkflowTestSuite
+			env := testSuite.NewTestActivityEnvironment()
+			env.RegisterActivity(recurringActivities)
+			value, err := env.ExecuteActivity(recurringActivities.ChargeRecurringPayment, testContract, testTerm)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = value.Get(&results[i])
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("attempt %d failed: %v", i+1, err)
+		}
+	}
+	if n := payments.Load(); n != 1 {
+		t.Errorf("customer charged %d times, want 1", n)
+	}
+	want := "payment 1 of MYR 49.90"
+	for i, result := range results {
+		if result != want {
+			t.Errorf("attempt %d returned %q, want %q", i+1, result, want)
+		}
+	}
+	records, _ := ledger.ListCharges(context.Background(), testContract.ConsentID)
+	if len(records) != 1 || records[0].Result != want {
+		t.Errorf("ledger holds %v, want the one charge", records)
+	}
+}
+
+func TestPaymentProviderRetriesFailedPayment(t *testing.T) {
+	var calls int
+	provider := newPaymentProvider(func(ctx context.Context, consentID string, amount models.Money, idempotencyKey string) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("provider unavailable")
+		}
+		return fmt.Sprintf("payment %d", calls), nil
+	})
+
+	if _, err := provider.pay(context.Background(), testContract.ConsentID, testContract.Amount, testTerm.IdempotencyKey); err == nil {
+		t.Fatal("first payment succeeded, want the provider failure")
+	}
+	for range 2 {
+		result, err := provider.pay(context.Background(), testContract.ConsentID, testContract.Amount, testTerm.IdempotencyKey)
+		if err != nil {
+			t.Fatalf("payment failed: %v", err)
+		}
+		if result != "payment 2" {
+			t.Errorf("payment returned %q, want %q", result, "payment 2")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("provider charged %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,26 @@
+package activities
+
+import (
+	"context"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// SendSLAAlert notifies support that a manual order reached an SLA threshold
+// stand-in for a webhook or email integration
+func SendSLAAlert(ctx context.Context, request models.SLAAlertRequest) error {
+	logger := activity.GetLogger(ctx)
+
+	logger.Warn("SLA alert for manual order",
+		"level", request.Level,
+		"orderID", request.OrderID,
+		"workflowID", request.WorkflowID,
+		"businessUnit", request.BusinessUnit,
+		"priority", request.Priority,
+		"enteredAt", request.EnteredAt.Format(time.RFC3339),
+		"deadline", request.Deadline.Format(time.RFC3339))
+
+	return nil
+}
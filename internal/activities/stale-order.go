@@ -3,20 +3,58 @@ package activities
 import (
 	"context"
 	"temporal-playground/internal/models"
+	"time"
 
 	"go.temporal.io/sdk/activity"
 )
 
 // FinalizeStaleWorkflow performs final cleanup and resolution
-func FinalizeStaleWorkflow(ctx context.Context, request models.StaleWorkflowRequest) error {
+func (oa *OrderActivities) FinalizeStaleWorkflow(ctx context.Context, request models.StaleWorkflowRequest) error {
 	logger := activity.GetLogger(ctx)
 
-	resolution := request.Metadata["resolution"]
+	resolution := metadataString(request.Metadata, "resolution")
 	logger.Info("Finalizing stale workflow",
 		"originalWorkflowID", request.OriginalWorkflowID,
 		"resolution", resolution)
 
+	// a failed retry is what moves the order on to the manual queue
+	staleRetryError := metadataString(request.Metadata, "staleRetryError")
+	escalationPath := []string{models.OrderStageQuery, models.OrderStageStale}
+	if staleRetryError != "" {
+		escalationPath = append(escalationPath, models.OrderStageManual)
+	}
+
+	resolvedAt := metadataTime(request.Metadata, "resolvedAt")
+	if resolvedAt.IsZero() {
+		resolvedAt = time.Now()
+	}
+
+	if err := oa.saveOrder(ctx, models.OrderRecord{
+		OrderID:        request.OrderID,
+		Resolution:     resolution,
+		ResolvedBy:     metadataString(request.Metadata, "resolvedBy"),
+		BusinessUnit:   request.BusinessUnit,
+		Priority:       request.Priority,
+		StartedAt:      metadataTime(request.Metadata, "originalWorkflowStartTime"),
+		ResolvedAt:     resolvedAt,
+		ErrorHistory:   models.NonEmpty(request.OriginalError, staleRetryError),
+		EscalationPath: escalationPath,
+	}); err != nil {
+		return err
+	}
+
 	logger.Info("Stale workflow finalized successfully", "resolution", resolution)
 
 	return nil
 }
+
+// metadataString reads a string from workflow metadata, which arrives as decoded JSON
+func metadataString(metadata map[string]any, key string) string {
+	value, _ := metadata[key].(string)
+	return value
+}
+
+func metadataTime(metadata map[string]any, key string) time.Time {
+	value, _ := time.Parse(time.RFC3339Nano, metadataString(metadata, key))
+	return value
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"temporal-playground/internal/orders"
+	"time"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// route describes an API endpoint, used both to register the handler and to generate the OpenAPI spec
+type route struct {
+	Method   string
+	Path     string
+	Summary  string
+	Request  any // nil when the endpoint takes no body
+	Response any // nil when the endpoint returns no body
+	Status   int
+	handler  http.HandlerFunc
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{Method: http.MethodPost, Path: "/orders/{orderID}/query", Summary: "Submit an order to be queried, idempotent per order ID", Request: SubmitOrderRequest{}, Response: orders.SubmitOrderResult{}, Status: http.StatusAccepted, handler: s.submitOrder},
+		{Method: http.MethodGet, Path: "/orders/{orderID}", Summary: "Get the status of an order across its query, stale and manual workflows", Response: orders.OrderStatus{}, Status: http.StatusOK, handler: s.getOrder},
+		{Method: http.MethodPost, Path: "/orders/{orderID}/resolve", Summary: "Resolve an order waiting for manual intervention", Request: ResolveOrderRequest{}, Response: orders.ResolveOrderResult{}, Status: http.StatusAccepted, handler: s.resolveOrder},
+		{Method: http.MethodPost, Path: "/recurring-payments", Summary: "Create a recurring payment schedule", Request: CreateRecurringPaymentRequest{}, Response: orders.RecurringPayment{}, Status: http.StatusCreated, handler: s.createRecurringPayment},
+		{Method: http.MethodGet, Path: "/recurring-payments", Summary: "List recurring payment schedules", Response: []orders.RecurringPayment{}, Status: http.StatusOK, handler: s.listRecurringPayments},
+		{Method: http.MethodGet, Path: "/recurring-payments/{consentID}", Summary: "Get a recurring payment schedule", Response: orders.RecurringPayment{}, Status: http.StatusOK, handler: s.getRecurringPayment},
+		{Method: http.MethodPatch, Path: "/recurring-payments/{consentID}", Summary: "Update the remaining terms of a recurring payment", Request: UpdateRecurringPaymentRequest{}, Response: orders.RecurringPayment{}, Status: http.StatusOK, handler: s.updateRecurringPayment},
+		{Method: http.MethodDelete, Path: "/recurring-payments/{consentID}", Summary: "Cancel a recurring payment", Status: http.StatusNoContent, handler: s.deleteRecurringPayment},
+	}
+}
+
+// OpenAPISpec generates an OpenAPI 3 document from the route table and the request/response types
+func (s *Server) OpenAPISpec() map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]map[string]any{}
+
+	for _, route := range s.routes() {
+		operation := map[string]any{
+			"summary":   route.Summary,
+			"responses": map[string]any{},
+		}
+
+		var parameters []map[string]any
+		for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+			parameters = append(parameters, map[string]any{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		if parameters != nil {
+			operation["parameters"] = parameters
+		}
+
+		if route.Request != nil {
+			requestType := reflect.TypeOf(route.Request)
+			schema := schemaFor(requestType, schemas)
+			// a body whose fields are all optional may be omitted
+			_, hasRequired := schemas[requestType.Name()].(map[string]any)["required"]
+			operation["requestBody"] = map[string]any{
+				"required": hasRequired,
+				"content":  jsonContent(schema),
+			}
+		}
+
+		responses := operation["responses"].(map[string]any)
+		success := map[string]any{"description": http.StatusText(route.Status)}
+		if route.Response != nil {
+			success["content"] = jsonContent(schemaFor(reflect.TypeOf(route.Response), schemas))
+		}
+		responses[strconv.Itoa(route.Status)] = success
+		responses["default"] = map[string]any{
+			"description": "Error",
+			"content":     jsonContent(schemaFor(reflect.TypeOf(ErrorResponse{}), schemas)),
+		}
+
+		if paths[route.Path] == nil {
+			paths[route.Path] = map[string]any{}
+		}
+		paths[route.Path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "temporal-playground order API",
+			"version": "1.0.0",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// schemaFor returns the JSON schema of a type, registering named structs as components
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if _, ok := schemas[t.Name()]; !ok {
+			schemas[t.Name()] = map[string]any{} // placeholder for recursive types
+			properties := map[string]any{}
+			var required []string
+			for i := range t.NumField() {
+				field := t.Field(i)
+				name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if !field.IsExported() || name == "-" {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				properties[name] = schemaFor(field.Type, schemas)
+				if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+					required = append(required, name)
+				}
+			}
+			schema := map[string]any{"type": "object", "properties": properties}
+			if required != nil {
+				schema["required"] = required
+			}
+			schemas[t.Name()] = schema
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"temporal-playground/internal/httputil"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/orders"
+)
+
+// CreateRecurringPaymentRequest represents a new recurring payment contract, with the schedule options of
+// create-recurring-payment. Exactly one of interval, cron, dayOfMonth, weekday or yearly is required
+type CreateRecurringPaymentRequest struct {
+	ConsentID      string            `json:"consentID"`
+	CustomerID     string            `json:"customerID,omitempty"`
+	Amount         models.Money      `json:"amount"` // in minor units, e.g. {"minor": 1550, "currency": "MYR"}
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	Terms          int               `json:"terms,omitempty"`         // 0 means infinite
+	Interval       string            `json:"interval,omitempty"`      // Go duration, e.g. 720h
+	Cron           string            `json:"cron,omitempty"`          // e.g. "0 9 1 * *"
+	DaysOfMonth    string            `json:"dayOfMonth,omitempty"`    // e.g. "1,15" or "last"
+	Weekdays       string            `json:"weekday,omitempty"`       // e.g. "mon,thu"
+	YearlyDates    []string          `json:"yearly,omitempty"`        // MM-DD
+	TimeOfDay      string            `json:"at,omitempty"`            // HH:MM of calendar payments, defaults to 00:00
+	TimeZone       string            `json:"timeZone,omitempty"`      // defaults to Asia/Kuala_Lumpur
+	Start          string            `json:"start,omitempty"`         // YYYY-MM-DD or RFC3339
+	End            string            `json:"end,omitempty"`           // YYYY-MM-DD, which takes the payments due that day, or RFC3339
+	Jitter         string            `json:"jitter,omitempty"`        // Go duration
+	SkipDates      []string          `json:"skip,omitempty"`          // YYYY-MM-DD
+	Dunning        string            `json:"dunning,omitempty"`       // e.g. "1d,3d,7d" (the default)
+	Overlap        string            `json:"overlap,omitempty"`       // skip, buffer-one (the default), buffer-all, cancel-other, terminate-other or allow-all
+	CatchupWindow  string            `json:"catchupWindow,omitempty"` // Go duration, defaults to 24h
+	PauseOnFailure bool              `json:"pauseOnFailure,omitempty"`
+	Environment    string            `json:"environment,omitempty"`
+	BusinessUnit   string            `json:"businessUnit,omitempty"`
+	Priority       string            `json:"priority,omitempty"`
+}
+
+// UpdateRecurringPaymentRequest represents a change to the remaining terms of a recurring payment
+type UpdateRecurringPaymentRequest struct {
+	Terms int `json:"terms"` // 0 means infinite
+}
+
+func (s *Server) createRecurringPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	body := CreateRecurringPaymentRequest{Environment: "development", BusinessUnit: "retail", Priority: "normal"}
+	if err := httputil.DecodeJSON(w, r, &body); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	request := orders.CreateRecurringPaymentRequest{
+		ConsentID:    body.ConsentID,
+		CustomerID:   body.CustomerID,
+		Amount:       body.Amount,
+		Metadata:     body.Metadata,
+		Terms:        body.Terms,
+		Environment:  body.Environment,
+		BusinessUnit: body.BusinessUnit,
+		Priority:     body.Priority,
+	}
+	err := orders.ScheduleOptions{
+		Interval:       body.Interval,
+		Cron:           body.Cron,
+		DaysOfMonth:    body.DaysOfMonth,
+		Weekdays:       body.Weekdays,
+		YearlyDates:    body.YearlyDates,
+		TimeOfDay:      body.TimeOfDay,
+		TimeZone:       body.TimeZone,
+		Start:          body.Start,
+		End:            body.End,
+		Jitter:         body.Jitter,
+		SkipDates:      body.SkipDates,
+		Dunning:        body.Dunning,
+		Overlap:        body.Overlap,
+		CatchupWindow:  body.CatchupWindow,
+		PauseOnFailure: body.PauseOnFailure,
+	}.Apply(&request)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	payment, err := s.orderService.CreateRecurringPayment(ctx, request)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, payment)
+}
+
+func (s *Server) listRecurringPayments(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	payments, err := s.orderService.ListRecurringPayments(ctx)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, payments)
+}
+
+func (s *Server) getRecurringPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	payment, err := s.orderService.GetRecurringPayment(ctx, r.PathValue("consentID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, payment)
+}
+
+func (s *Server) updateRecurringPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	var body UpdateRecurringPaymentRequest
+	if err := httputil.DecodeJSON(w, r, &body); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	payment, err := s.orderService.UpdateRecurringPaymentTerms(ctx, r.PathValue("consentID"), body.Terms)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, payment)
+}
+
+func (s *Server) deleteRecurringPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	if err := s.orderService.CancelRecurringPayment(ctx, r.PathValue("consentID")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"temporal-playground/internal/httputil"
+	"temporal-playground/internal/orders"
+	"time"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+)
+
+// Server exposes order submission, status, resolution and recurring payment management over HTTP/JSON
+type Server struct {
+	orderService *orders.Service
+}
+
+func NewServer(orderService *orders.Service) *Server {
+	return &Server{
+		orderService: orderService,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, route := range s.routes() {
+		mux.HandleFunc(route.Method+" "+route.Path, route.handler)
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		httputil.WriteJSON(w, http.StatusOK, s.OpenAPISpec())
+	})
+	return mux
+}
+
+// SubmitOrderRequest represents a payment service submitting an order to be queried
+type SubmitOrderRequest struct {
+	Environment  string `json:"environment,omitempty"`
+	BusinessUnit string `json:"businessUnit,omitempty"`
+	Priority     string `json:"priority,omitempty"`
+	Lifecycle    bool   `json:"lifecycle,omitempty"`
+}
+
+// ResolveOrderRequest represents a manual resolution of an order
+type ResolveOrderRequest struct {
+	Resolution string `json:"resolution"`
+	Operator   string `json:"operator,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// ErrorResponse represents a failed request, as written by httputil.WriteError
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) submitOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	body := SubmitOrderRequest{Environment: "development", BusinessUnit: "retail", Priority: "normal"}
+	// the body is optional, an empty one keeps the defaults
+	if err := httputil.DecodeJSON(w, r, &body); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.orderService.SubmitOrder(ctx, orders.SubmitOrderRequest{
+		OrderID:      r.PathValue("orderID"),
+		Environment:  body.Environment,
+		BusinessUnit: body.BusinessUnit,
+		Priority:     body.Priority,
+		Lifecycle:    body.Lifecycle,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if result.Duplicate {
+		status = http.StatusOK
+	}
+	httputil.WriteJSON(w, status, result)
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	status, err := s.orderService.GetOrderStatus(ctx, r.PathValue("orderID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) resolveOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	var body ResolveOrderRequest
+	if err := httputil.DecodeJSON(w, r, &body); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.orderService.ResolveOrder(ctx, orders.ResolveOrderRequest{
+		OrderID:    r.PathValue("orderID"),
+		Resolution: body.Resolution,
+		Operator:   body.Operator,
+		Comment:    body.Comment,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusAccepted, result)
+}
+
+// writeServiceError maps order service errors to HTTP status codes
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, orders.ErrInvalidArgument):
+		httputil.WriteError(w, http.StatusBadRequest, err)
+	case errors.Is(err, orders.ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, err)
+	case errors.Is(err, orders.ErrConflict):
+		httputil.WriteError(w, http.StatusConflict, err)
+	default:
+		httputil.WriteError(w, http.StatusBadGateway, err)
+	}
+}
@@ -0,0 +1,84 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+)
+
+const workflowIDPrefix = "circuit-breaker-"
+
+// Client keeps the circuit breakers as one long-running CircuitBreaker workflow per upstream provider,
+// so every worker shares the same breaker state
+type Client struct {
+	workflowManager *temporal.WorkflowManager
+	taskQueue       string
+}
+
+func NewClient(workflowManager *temporal.WorkflowManager, taskQueue string) *Client {
+	return &Client{
+		workflowManager: workflowManager,
+		taskQueue:       taskQueue,
+	}
+}
+
+// WorkflowID returns the ID of the breaker workflow of a provider
+func WorkflowID(provider string) string {
+	return workflowIDPrefix + provider
+}
+
+// State returns the breaker state of a provider; a provider without a breaker workflow is closed
+func (c *Client) State(ctx context.Context, provider string) (models.BreakerState, error) {
+	var state models.BreakerState
+	err := c.workflowManager.QueryWorkflow(ctx, WorkflowID(provider), "", workflows.QueryBreakerState, &state)
+	if temporal.IsNotFound(err) {
+		return models.BreakerState{Provider: provider, State: models.BreakerClosed}, nil
+	}
+	return state, err
+}
+
+// Report signals the outcome of an upstream call, starting the provider's breaker on its first report
+func (c *Client) Report(ctx context.Context, provider string, report models.BreakerReport) error {
+	_, err := c.workflowManager.SignalWithStartWorkflow(ctx, WorkflowID(provider), c.taskQueue, workflows.SignalBreakerReport, report,
+		workflows.CircuitBreaker, models.BreakerState{Provider: provider, Policy: report.Policy})
+	return err
+}
+
+// Reset forces the breaker of a provider closed or open
+func (c *Client) Reset(ctx context.Context, provider string, reset models.BreakerReset) error {
+	if reset.State != models.BreakerClosed && reset.State != models.BreakerOpen {
+		return fmt.Errorf("a breaker can only be forced %s or %s, not %q", models.BreakerClosed, models.BreakerOpen, reset.State)
+	}
+	_, err := c.workflowManager.SignalWithStartWorkflow(ctx, WorkflowID(provider), c.taskQueue, workflows.SignalBreakerReset, reset,
+		workflows.CircuitBreaker, models.BreakerState{Provider: provider, Policy: activities.DefaultBreakerPolicy})
+	return err
+}
+
+// List returns the state of every running breaker
+func (c *Client) List(ctx context.Context) ([]models.BreakerState, error) {
+	var (
+		states        []models.BreakerState
+		nextPageToken []byte
+	)
+	for {
+		response, err := c.workflowManager.ListWorkflows(ctx, "WorkflowType = 'CircuitBreaker' AND ExecutionStatus = 'Running'", 100, nextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, execution := range response.GetExecutions() {
+			var state models.BreakerState
+			if err := c.workflowManager.QueryWorkflow(ctx, execution.GetExecution().GetWorkflowId(), "", workflows.QueryBreakerState, &state); err != nil {
+				return nil, fmt.Errorf("failed to query breaker %s: %w", execution.GetExecution().GetWorkflowId(), err)
+			}
+			states = append(states, state)
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			return states, nil
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package console
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"temporal-playground/internal/httputil"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+	"time"
+)
+
+const (
+	// OrderLifecycle workflows are listed once they reach the manual stage
+	openManualCasesQuery = "(WorkflowType = 'ManualHandleOrder' OR (WorkflowType = 'OrderLifecycle' AND CustomKeywordField = 'manual')) AND ExecutionStatus = 'Running'"
+	casesPageSize        = 100
+	requestTimeout       = 10 * time.Second
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// CaseSummary represents an open manual case in the queue listing
+type CaseSummary struct {
+	WorkflowID string    `json:"workflowID"`
+	RunID      string    `json:"runID"`
+	OrderID    string    `json:"orderID"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+// CaseDetails represents a manual case with its original request, assignment and SLA state
+type CaseDetails struct {
+	WorkflowID string                     `json:"workflowID"`
+	Request    models.ManualHandleRequest `json:"request"`
+	Case       models.ManualCaseState     `json:"case"`
+	SLA        models.SLAState            `json:"sla"`
+}
+
+// CaseActionRequest represents an operator action submitted from the console
+type CaseActionRequest struct {
+	Operator   string `json:"operator"`
+	Assignee   string `json:"assignee,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Override   bool   `json:"override,omitempty"` // reassign or resolve a case claimed by another operator
+}
+
+// Server serves the operator console UI and its JSON API for the manual-handle queue
+type Server struct {
+	workflowManager *temporal.WorkflowManager
+}
+
+func NewServer(workflowManager *temporal.WorkflowManager) *Server {
+	return &Server{
+		workflowManager: workflowManager,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		log.Panicf("Console static files are missing: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.HandleFunc("GET /api/cases", s.listCases)
+	mux.HandleFunc("GET /api/cases/{workflowID}", s.getCase)
+	mux.HandleFunc("POST /api/cases/{workflowID}/resolve", s.caseAction(models.CaseActionResolve))
+	mux.HandleFunc("POST /api/cases/{workflowID}/reassign", s.caseAction(models.CaseActionReassign))
+	mux.HandleFunc("POST /api/cases/{workflowID}/claim", s.caseAction(models.CaseActionClaim))
+	return mux
+}
+
+func (s *Server) listCases(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	cases := []CaseSummary{}
+	var nextPageToken []byte
+	for {
+		response, err := s.workflowManager.ListWorkflows(ctx, openManualCasesQuery, casesPageSize, nextPageToken)
+		if err != nil {
+			httputil.WriteError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		for _, execution := range response.GetExecutions() {
+			cases = append(cases, CaseSummary{
+				WorkflowID: execution.GetExecution().GetWorkflowId(),
+				RunID:      execution.GetExecution().GetRunId(),
+				OrderID:    temporal.SearchAttributeString(execution.GetSearchAttributes(), "CustomStringField"),
+				StartTime:  execution.GetStartTime().AsTime(),
+			})
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, cases)
+}
+
+func (s *Server) getCase(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	details := CaseDetails{WorkflowID: r.PathValue("workflowID")}
+	description, err := s.workflowManager.DescribeWorkflow(ctx, details.WorkflowID, "")
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+	// the manual stage of an OrderLifecycle has no ManualHandleRequest input, so it serves the request as a query
+	if description.GetWorkflowExecutionInfo().GetType().GetName() == "OrderLifecycle" {
+		err = s.workflowManager.QueryWorkflow(ctx, details.WorkflowID, "", workflows.QueryManualRequest, &details.Request)
+	} else {
+		err = s.workflowManager.GetWorkflowInput(ctx, details.WorkflowID, "", &details.Request)
+	}
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+	if err := s.workflowManager.QueryWorkflow(ctx, details.WorkflowID, "", workflows.QueryManualCaseState, &details.Case); err != nil {
+		httputil.WriteError(w, http.StatusBadGateway, err)
+		return
+	}
+	if err := s.workflowManager.QueryWorkflow(ctx, details.WorkflowID, "", workflows.QueryManualSLAState, &details.SLA); err != nil {
+		httputil.WriteError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) caseAction(action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+
+		var body CaseActionRequest
+		if err := httputil.DecodeJSON(w, r, &body); err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+		if body.Operator == "" {
+			httputil.WriteError(w, http.StatusBadRequest, errors.New("operator is required"))
+			return
+		}
+
+		caseAction := models.ManualCaseAction{
+			Action:     action,
+			Operator:   body.Operator,
+			Assignee:   body.Assignee,
+			Resolution: body.Resolution,
+			Comment:    body.Comment,
+			Override:   body.Override,
+		}
+		workflowID := r.PathValue("workflowID")
+		if err := s.workflowManager.SignalWorkflow(ctx, workflowID, "", workflows.SignalManualCaseAction, caseAction); err != nil {
+			httputil.WriteError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		log.Printf("Console: '%s' by '%s' sent to manual workflow %s", action, body.Operator, workflowID)
+		httputil.WriteJSON(w, http.StatusAccepted, caseAction)
+	}
+}
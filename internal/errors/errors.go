@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// UpstreamThrottled is the error of a provider answering 429 Too Many Requests
+const UpstreamThrottled = "Upstream provider throttled the request"
+
 var Errors = []string{
 	"Order not found",
 	"Invalid order ID",
@@ -14,9 +17,21 @@ var Errors = []string{
 	"Internal server error",
 	"Database connection timeout",
 	"Unknown error",
+	UpstreamThrottled,
 }
 
 func GetRandomError() string {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	return Errors[r.Intn(len(Errors))]
 }
+
+// IsUpstreamFailure reports whether the error means the upstream provider itself is failing,
+// as opposed to rejecting this order; only these count towards opening the upstream's circuit breaker
+func IsUpstreamFailure(errorType string) bool {
+	switch errorType {
+	case "Payment provider returns internal error", "Internal server error", "Database connection timeout":
+		return true
+	default:
+		return false
+	}
+}
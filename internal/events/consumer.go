@@ -0,0 +1,211 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"temporal-playground/internal/orders"
+	"time"
+)
+
+const (
+	initialRetryInterval = time.Second
+	maximumRetryInterval = time.Minute
+)
+
+// OrderSubmitter starts the workflow for an order, reporting an already submitted order as a duplicate
+type OrderSubmitter interface {
+	SubmitOrder(ctx context.Context, request orders.SubmitOrderRequest) (orders.SubmitOrderResult, error)
+}
+
+// ConsumerOptions configures how events are consumed
+type ConsumerOptions struct {
+	MaxInFlight int // events fetched but not yet committed; fetching blocks once reached
+
+	// used when the event does not carry them
+	Environment  string
+	BusinessUnit string
+	Priority     string
+}
+
+// Consumer turns payment pending events into QueryOrder workflow starts. Events are handled concurrently,
+// but offsets are committed in fetch order and only once the start succeeded or the event was dead-lettered
+type Consumer struct {
+	source      Source
+	deadLetters DeadLetterSink
+	submitter   OrderSubmitter
+	options     ConsumerOptions
+}
+
+type inFlight struct {
+	message Message
+	done    chan error
+}
+
+func NewConsumer(source Source, deadLetters DeadLetterSink, submitter OrderSubmitter, options ConsumerOptions) *Consumer {
+	if options.MaxInFlight <= 0 {
+		options.MaxInFlight = 1
+	}
+
+	return &Consumer{
+		source:      source,
+		deadLetters: deadLetters,
+		submitter:   submitter,
+		options:     options,
+	}
+}
+
+// Run consumes until the source is exhausted or the context is cancelled
+func (c *Consumer) Run(ctx context.Context) error {
+	pending := make(chan *inFlight, c.options.MaxInFlight)
+	commitResult := make(chan error, 1)
+	go func() {
+		commitResult <- c.commitInOrder(ctx, pending)
+	}()
+
+	var fetchErr error
+	for {
+		message, err := c.source.Fetch(ctx)
+		if err != nil {
+			fetchErr = err
+			break
+		}
+
+		// stop at the first commit failure, before handling events that could no longer be committed
+		select {
+		case err := <-commitResult:
+			return err
+		default:
+		}
+
+		item := &inFlight{message: message, done: make(chan error, 1)}
+		select {
+		case pending <- item: // backpressure: blocks while MaxInFlight events are uncommitted
+		case err := <-commitResult:
+			return err
+		}
+		go func() {
+			item.done <- c.handle(ctx, message)
+		}()
+	}
+
+	close(pending)
+	commitErr := <-commitResult
+	switch {
+	case errors.Is(fetchErr, io.EOF):
+		return commitErr
+	case ctx.Err() != nil:
+		return nil
+	default:
+		return fetchErr
+	}
+}
+
+func (c *Consumer) commitInOrder(ctx context.Context, pending <-chan *inFlight) error {
+	for item := range pending {
+		if err := <-item.done; err != nil {
+			return err
+		}
+		if err := c.source.Commit(ctx, item.message); err != nil {
+			return fmt.Errorf("failed to commit event %s: %w", item.message.Position, err)
+		}
+	}
+	return nil
+}
+
+// handle starts the workflow for a single event, retrying transient failures until the context is cancelled.
+// A nil error means the event can be committed
+func (c *Consumer) handle(ctx context.Context, message Message) error {
+	var event PaymentPendingEvent
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		return c.deadLetter(ctx, message, fmt.Sprintf("invalid JSON: %v", err))
+	}
+	if event.Type == "" {
+		return c.deadLetter(ctx, message, "missing event type")
+	}
+	if event.Type != EventTypePaymentPending {
+		log.Printf("Skipping %s event at %s", event.Type, message.Position)
+		return nil
+	}
+
+	request := orders.SubmitOrderRequest{
+		OrderID:      event.OrderID,
+		Environment:  valueOrDefault(event.Environment, c.options.Environment),
+		BusinessUnit: valueOrDefault(event.BusinessUnit, c.options.BusinessUnit),
+		Priority:     valueOrDefault(event.Priority, c.options.Priority),
+	}
+
+	var (
+		result     orders.SubmitOrderResult
+		invalidErr error
+	)
+	err := retry(ctx, "start workflow for "+message.Position, func() error {
+		var err error
+		result, err = c.submitter.SubmitOrder(ctx, request)
+		if errors.Is(err, orders.ErrInvalidArgument) {
+			invalidErr = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if invalidErr != nil {
+		return c.deadLetter(ctx, message, invalidErr.Error())
+	}
+
+	if result.Duplicate {
+		log.Printf("Order %s is already being processed (workflow %s) - skipping event at %s", event.OrderID, result.WorkflowID, message.Position)
+	} else {
+		log.Printf("Started workflow %s for event at %s", result.WorkflowID, message.Position)
+	}
+	return nil
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, message Message, reason string) error {
+	log.Printf("Dead-lettering event at %s: %s", message.Position, reason)
+
+	deadLetter := DeadLetter{
+		Position: message.Position,
+		Key:      string(message.Key),
+		Value:    string(message.Value),
+		Reason:   reason,
+		FailedAt: time.Now(),
+	}
+	return retry(ctx, "dead-letter "+message.Position, func() error {
+		return c.deadLetters.Send(ctx, deadLetter)
+	})
+}
+
+// retry calls fn with exponential backoff until it succeeds or the context is cancelled
+func retry(ctx context.Context, what string, fn func() error) error {
+	interval := initialRetryInterval
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.Printf("Failed to %s, retrying in %s: %v", what, interval, err)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		interval = min(interval*2, maximumRetryInterval)
+	}
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
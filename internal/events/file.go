@@ -0,0 +1,100 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileSource reads JSONL events from a file, or from stdin when the path is "-", for local testing.
+// Commit is a no-op since a file has no consumer offsets to store
+type FileSource struct {
+	file    *os.File
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewFileSource(path string) (*FileSource, error) {
+	file := os.Stdin
+	if path != "-" {
+		var err error
+		if file, err = os.Open(path); err != nil {
+			return nil, fmt.Errorf("failed to open event file '%s': %w", path, err)
+		}
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	return &FileSource{
+		file:    file,
+		scanner: scanner,
+	}, nil
+}
+
+func (fs *FileSource) Fetch(ctx context.Context) (Message, error) {
+	for fs.scanner.Scan() {
+		fs.line++
+		if len(fs.scanner.Bytes()) == 0 {
+			continue
+		}
+		return Message{
+			Value:    append([]byte(nil), fs.scanner.Bytes()...),
+			Position: fmt.Sprintf("%s:%d", fs.file.Name(), fs.line),
+		}, nil
+	}
+	if err := fs.scanner.Err(); err != nil {
+		return Message{}, err
+	}
+	return Message{}, io.EOF
+}
+
+func (fs *FileSource) Commit(ctx context.Context, message Message) error {
+	return nil
+}
+
+func (fs *FileSource) Close() error {
+	if fs.file == os.Stdin {
+		return nil
+	}
+	return fs.file.Close()
+}
+
+// FileDeadLetterSink appends dead letters as JSONL to a file, or to stderr when the path is "-"
+type FileDeadLetterSink struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewFileDeadLetterSink(path string) (*FileDeadLetterSink, error) {
+	file := os.Stderr
+	if path != "-" {
+		var err error
+		if file, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to open dead-letter file '%s': %w", path, err)
+		}
+	}
+
+	return &FileDeadLetterSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (fs *FileDeadLetterSink) Send(ctx context.Context, deadLetter DeadLetter) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.encoder.Encode(deadLetter)
+}
+
+func (fs *FileDeadLetterSink) Close() error {
+	if fs.file == os.Stderr {
+		return nil
+	}
+	return fs.file.Close()
+}
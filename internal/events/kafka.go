@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSource consumes events from a Kafka topic as part of a consumer group,
+// committing offsets explicitly instead of on fetch
+type KafkaSource struct {
+	reader *kafka.Reader
+}
+
+func NewKafkaSource(brokers []string, topic string, groupID string) *KafkaSource {
+	return &KafkaSource{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   topic,
+			GroupID: groupID,
+		}),
+	}
+}
+
+func (ks *KafkaSource) Fetch(ctx context.Context) (Message, error) {
+	message, err := ks.reader.FetchMessage(ctx)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Key:      message.Key,
+		Value:    message.Value,
+		Position: fmt.Sprintf("%s/%d@%d", message.Topic, message.Partition, message.Offset),
+		raw:      message,
+	}, nil
+}
+
+func (ks *KafkaSource) Commit(ctx context.Context, message Message) error {
+	return ks.reader.CommitMessages(ctx, message.raw.(kafka.Message))
+}
+
+func (ks *KafkaSource) Close() error {
+	return ks.reader.Close()
+}
+
+// KafkaDeadLetterSink publishes dead letters to a Kafka topic, keyed by the original message key
+type KafkaDeadLetterSink struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaDeadLetterSink(brokers []string, topic string) *KafkaDeadLetterSink {
+	return &KafkaDeadLetterSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (ks *KafkaDeadLetterSink) Send(ctx context.Context, deadLetter DeadLetter) error {
+	value, err := json.Marshal(deadLetter)
+	if err != nil {
+		return err
+	}
+	return ks.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(deadLetter.Key),
+		Value: value,
+	})
+}
+
+func (ks *KafkaDeadLetterSink) Close() error {
+	return ks.writer.Close()
+}
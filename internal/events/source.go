@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EventTypePaymentPending = "payment.pending"
+)
+
+// Message is a raw event read from a source, committed back to the same source once handled
+type Message struct {
+	Key      []byte
+	Value    []byte
+	Position string // partition/offset or line number, for logs and dead letters
+	raw      any    // source specific handle needed to commit the message
+}
+
+// Source is a stream of events to consume. Fetch blocks until a message is available
+// and returns io.EOF once a finite source is exhausted
+type Source interface {
+	Fetch(ctx context.Context) (Message, error)
+	Commit(ctx context.Context, message Message) error
+	Close() error
+}
+
+// DeadLetter represents an event that could not be turned into a workflow start
+type DeadLetter struct {
+	Position string    `json:"position"`
+	Key      string    `json:"key,omitempty"`
+	Value    string    `json:"value"`
+	Reason   string    `json:"reason"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// DeadLetterSink stores malformed events so they can be inspected and replayed
+type DeadLetterSink interface {
+	Send(ctx context.Context, deadLetter DeadLetter) error
+	Close() error
+}
+
+// PaymentPendingEvent represents a payment service reporting an order whose payment outcome is still unknown
+type PaymentPendingEvent struct {
+	Type         string    `json:"type"`
+	OrderID      string    `json:"orderID"`
+	Environment  string    `json:"environment,omitempty"`
+	BusinessUnit string    `json:"businessUnit,omitempty"`
+	Priority     string    `json:"priority,omitempty"`
+	OccurredAt   time.Time `json:"occurredAt,omitempty"`
+}
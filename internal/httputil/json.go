@@ -0,0 +1,31 @@
+package httputil
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// WriteJSON writes body as a JSON response with the given status code
+func WriteJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
+
+// WriteError writes an error as a JSON response of the form {"error": "..."}
+func WriteError(w http.ResponseWriter, status int, err error) {
+	WriteJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// MaxBodyBytes bounds the JSON request bodies DecodeJSON reads
+const MaxBodyBytes = 1 << 20
+
+// DecodeJSON decodes a JSON request body of up to MaxBodyBytes, rejecting unknown fields
+func DecodeJSON(w http.ResponseWriter, r *http.Request, body any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(body)
+}
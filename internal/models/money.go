@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// currencyExponents holds the currencies whose minor unit is not a hundredth of the major unit
+var currencyExponents = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyExponent returns the number of decimal places of the currency's minor unit
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// ParseMoney parses a decimal amount in the major unit of the currency, e.g. "15.50" MYR is 1550 minor units
+func ParseMoney(amount string, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	if !currencyCode.MatchString(currency) {
+		return Money{}, fmt.Errorf("invalid currency %q, expected an ISO 4217 code", currency)
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)))
+	if !value.IsInt() {
+		return Money{}, fmt.Errorf("amount %q has more decimal places than %s allows", amount, currency)
+	}
+	if !value.Num().IsInt64() {
+		return Money{}, fmt.Errorf("amount %q is too large", amount)
+	}
+	return Money{Minor: value.Num().Int64(), Currency: currency}, nil
+}
+
+// Validate checks that the amount is positive and the currency is an ISO 4217 code
+func (m Money) Validate() error {
+	if !currencyCode.MatchString(m.Currency) {
+		return fmt.Errorf("invalid currency %q, expected an ISO 4217 code", m.Currency)
+	}
+	if m.Minor <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	return nil
+}
+
+// String formats the amount in the major unit of the currency, e.g. "MYR 15.50"
+func (m Money) String() string {
+	exponent := CurrencyExponent(m.Currency)
+	if exponent == 0 {
+		return fmt.Sprintf("%s %d", m.Currency, m.Minor)
+	}
+
+	sign, minor := "", m.Minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	scale := int64(1)
+	for range exponent {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s %s%d.%0*d", m.Currency, sign, minor/scale, exponent, minor%scale)
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Resolution status constants
 const (
@@ -10,6 +13,26 @@ const (
 	ResolutionManualResolve = "manual-resolve"
 	ResolutionMovedToStale  = "moved-to-stale"
 	ResolutionMovedToManual = "moved-to-manual"
+	ResolutionAutoResolved  = "auto-resolved"
+)
+
+// Manual case action constants
+const (
+	CaseActionOpen     = "open"
+	CaseActionClaim    = "claim"
+	CaseActionUnclaim  = "unclaim"
+	CaseActionReassign = "reassign"
+	CaseActionComment  = "comment"
+	CaseActionResolve  = "resolve"
+	CaseActionAlert    = "sla-alert"
+)
+
+// SLA status constants
+const (
+	SLAStatusWithin   = "within-sla"
+	SLAStatusWarning  = "warning"
+	SLAStatusBreached = "breached"
+	SLAStatusResolved = "resolved"
 )
 
 // StaleWorkflowRequest represents the data for a workflow that has failed after all retries
@@ -21,6 +44,9 @@ type StaleWorkflowRequest struct {
 	FailureTime        time.Time      `json:"failureTime"`
 	MaxAttemptsReached int32          `json:"maxAttemptsReached"`
 	OriginalError      string         `json:"originalError,omitempty"`
+	BusinessUnit       string         `json:"businessUnit,omitempty"`
+	Priority           string         `json:"priority,omitempty"`
+	Environment        string         `json:"environment,omitempty"`
 	Metadata           map[string]any `json:"metadata,omitempty"`
 }
 
@@ -34,14 +60,330 @@ type ManualHandleRequest struct {
 	StaleFailureTime   time.Time      `json:"staleFailureTime"`
 	OriginalError      string         `json:"originalError,omitempty"`
 	StaleRetryError    string         `json:"staleRetryError,omitempty"`
+	BusinessUnit       string         `json:"businessUnit,omitempty"`
+	Priority           string         `json:"priority,omitempty"`
+	SLA                *SLAPolicy     `json:"sla,omitempty"` // overrides the policy looked up by business unit and priority
 	Metadata           map[string]any `json:"metadata,omitempty"`
 }
 
 // ConcludeQueryOrderRequest represents the data for concluding an order
 type ConcludeQueryOrderRequest struct {
-	OrderID            string    `json:"orderID"`
-	Resolution         string    `json:"resolution"`
-	OriginalWorkflowID string    `json:"originalWorkflowID"`
-	ResolvedAt         time.Time `json:"resolvedAt"`
-	ResolvedBy         string    `json:"resolvedBy,omitempty"`
+	OrderID            string       `json:"orderID"`
+	Resolution         string       `json:"resolution"`
+	OriginalWorkflowID string       `json:"originalWorkflowID"`
+	ResolvedAt         time.Time    `json:"resolvedAt"`
+	ResolvedBy         string       `json:"resolvedBy,omitempty"`
+	AuditTrail         []AuditEntry `json:"auditTrail,omitempty"`
+	BusinessUnit       string       `json:"businessUnit,omitempty"`
+	Priority           string       `json:"priority,omitempty"`
+	StartedAt          time.Time    `json:"startedAt,omitempty"`
+	ErrorHistory       []string     `json:"errorHistory,omitempty"`
+	EscalationPath     []string     `json:"escalationPath,omitempty"`
+}
+
+// Order lifecycle stage constants
+const (
+	OrderStageQuery  = "query"
+	OrderStageStale  = "stale"
+	OrderStageManual = "manual"
+)
+
+// OrderLifecycleRequest represents the state of an OrderLifecycle workflow, carried across continue-as-new runs
+type OrderLifecycleRequest struct {
+	OrderID         string           `json:"orderID"`
+	Stage           string           `json:"stage,omitempty"`
+	StartedAt       time.Time        `json:"startedAt,omitempty"`
+	OriginalError   string           `json:"originalError,omitempty"`
+	StaleRetryError string           `json:"staleRetryError,omitempty"`
+	RunCount        int              `json:"runCount,omitempty"`
+	ManualSince     time.Time        `json:"manualSince,omitempty"`
+	ManualCase      *ManualCaseState `json:"manualCase,omitempty"`
+}
+
+// SLAPolicy represents the deadlines for an order waiting in the manual queue, relative to when it entered the queue.
+// A zero duration disables that threshold
+type SLAPolicy struct {
+	WarningAfter     time.Duration `json:"warningAfter"`
+	BreachAfter      time.Duration `json:"breachAfter"`
+	AutoResolveAfter time.Duration `json:"autoResolveAfter,omitempty"`
+	AutoResolution   string        `json:"autoResolution,omitempty"`
+}
+
+// SLAState represents the SLA progress of a manual workflow, returned by the SLA state query
+type SLAState struct {
+	OrderID       string     `json:"orderID"`
+	BusinessUnit  string     `json:"businessUnit,omitempty"`
+	Priority      string     `json:"priority,omitempty"`
+	Policy        SLAPolicy  `json:"policy"`
+	Status        string     `json:"status"`
+	EnteredAt     time.Time  `json:"enteredAt"`
+	WarningAt     *time.Time `json:"warningAt,omitempty"`
+	BreachAt      *time.Time `json:"breachAt,omitempty"`
+	AutoResolveAt *time.Time `json:"autoResolveAt,omitempty"`
+	AlertsSent    []string   `json:"alertsSent,omitempty"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// SLAAlertRequest represents the data for a reminder or escalation alert on a manual workflow
+type SLAAlertRequest struct {
+	OrderID      string    `json:"orderID"`
+	WorkflowID   string    `json:"workflowID"`
+	BusinessUnit string    `json:"businessUnit,omitempty"`
+	Priority     string    `json:"priority,omitempty"`
+	Level        string    `json:"level"`
+	EnteredAt    time.Time `json:"enteredAt"`
+	Deadline     time.Time `json:"deadline"`
+}
+
+// ManualCaseAction represents an operator action on a manual workflow, sent as a signal
+type ManualCaseAction struct {
+	Action     string `json:"action"`
+	Operator   string `json:"operator"`
+	Assignee   string `json:"assignee,omitempty"`   // reassign only
+	Comment    string `json:"comment,omitempty"`    // comment, or a note on any other action
+	Resolution string `json:"resolution,omitempty"` // resolve only
+	Override   bool   `json:"override,omitempty"`   // reassign or resolve a case claimed by another operator
+}
+
+// AuditEntry represents a single append-only entry in the audit trail of a manual workflow
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Operator string    `json:"operator"`
+	Detail   string    `json:"detail,omitempty"`
+	Rejected bool      `json:"rejected,omitempty"`
+}
+
+// ManualCaseState represents the assignment and audit trail of a manual workflow, returned by the case state query
+type ManualCaseState struct {
+	OrderID    string       `json:"orderID"`
+	Assignee   string       `json:"assignee,omitempty"`
+	AuditTrail []AuditEntry `json:"auditTrail"`
+}
+
+// OrderResolutionEvent represents the final outcome of an order, sent to the payment services as a webhook
+type OrderResolutionEvent struct {
+	EventID      string    `json:"eventID"`
+	OrderID      string    `json:"orderID"`
+	Resolution   string    `json:"resolution"` // success, retry-succeeded, manual-resolve or failed
+	Detail       string    `json:"detail,omitempty"`
+	ResolvedBy   string    `json:"resolvedBy,omitempty"`
+	ResolvedAt   time.Time `json:"resolvedAt"`
+	WorkflowID   string    `json:"workflowID"`
+	BusinessUnit string    `json:"businessUnit,omitempty"`
+}
+
+// WebhookDelivery represents one attempt to deliver an order resolution event to an endpoint
+type WebhookDelivery struct {
+	EventID      string    `json:"eventID"`
+	OrderID      string    `json:"orderID"`
+	Resolution   string    `json:"resolution"`
+	BusinessUnit string    `json:"businessUnit,omitempty"`
+	Endpoint     string    `json:"endpoint"`
+	Attempt      int32     `json:"attempt"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DeliveredAt  time.Time `json:"deliveredAt"`
+}
+
+// OrderRecord represents the final state of an order as concluded by one workflow run, persisted by the order repository
+type OrderRecord struct {
+	OrderID        string    `json:"orderID"`
+	WorkflowID     string    `json:"workflowID"`
+	RunID          string    `json:"runID"`
+	Resolution     string    `json:"resolution"`
+	ResolvedBy     string    `json:"resolvedBy,omitempty"`
+	BusinessUnit   string    `json:"businessUnit,omitempty"`
+	Priority       string    `json:"priority,omitempty"`
+	StartedAt      time.Time `json:"startedAt,omitempty"`
+	ResolvedAt     time.Time `json:"resolvedAt"`
+	ErrorHistory   []string  `json:"errorHistory,omitempty"`
+	EscalationPath []string  `json:"escalationPath,omitempty"` // order stages the order went through, e.g. query, stale, manual
+	RecordedAt     time.Time `json:"recordedAt"`
+}
+
+// NonEmpty drops the empty values, e.g. errors of stages an order did not reach
+func NonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// Circuit breaker state constants
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerPolicy represents the thresholds of an upstream circuit breaker
+type BreakerPolicy struct {
+	FailureThreshold  int           `json:"failureThreshold"`  // consecutive failures that open the breaker
+	OpenDuration      time.Duration `json:"openDuration"`      // how long the breaker stays open before letting calls probe the upstream
+	HalfOpenSuccesses int           `json:"halfOpenSuccesses"` // successful probes that close the breaker again
+}
+
+// BreakerReport represents the outcome of one upstream call, sent as a signal to the circuit breaker workflow
+type BreakerReport struct {
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+	Policy  BreakerPolicy `json:"policy"` // the reporting worker's policy, adopted by the breaker
+}
+
+// BreakerReset represents an operator forcing a circuit breaker closed or open
+type BreakerReset struct {
+	State    string `json:"state"`
+	Operator string `json:"operator"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// BreakerState represents the state of the circuit breaker of one upstream provider, returned by the breaker state query
+type BreakerState struct {
+	Provider            string        `json:"provider"`
+	State               string        `json:"state"`
+	Policy              BreakerPolicy `json:"policy"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	HalfOpenSuccesses   int           `json:"halfOpenSuccesses,omitempty"`
+	LastError           string        `json:"lastError,omitempty"`
+	OpenedAt            *time.Time    `json:"openedAt,omitempty"`
+	RetryAt             *time.Time    `json:"retryAt,omitempty"`  // when an open breaker turns half-open
+	ForcedBy            string        `json:"forcedBy,omitempty"` // operator who forced the breaker open; it stays open until reset
+	UpdatedAt           time.Time     `json:"updatedAt"`
+}
+
+// Pause scope constants
+const (
+	PauseScopeBusinessUnit = "business-unit"
+	PauseScopeEnvironment  = "environment"
+)
+
+// PauseRequest represents an operator pausing or resuming the processing of a business unit or environment,
+// sent as a signal to the processing control workflow
+type PauseRequest struct {
+	Scope    string `json:"scope"`
+	Value    string `json:"value"`
+	Resume   bool   `json:"resume,omitempty"`
+	Operator string `json:"operator"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// PausedScope represents a business unit or environment whose order workflows are held before their next query
+type PausedScope struct {
+	Scope    string    `json:"scope"`
+	Value    string    `json:"value"`
+	Operator string    `json:"operator"`
+	Reason   string    `json:"reason,omitempty"`
+	PausedAt time.Time `json:"pausedAt"`
+	Waiting  int       `json:"waiting"` // order workflows currently held
+}
+
+// PauseWaiter represents an order workflow held by a paused scope, to be signalled when the scope resumes
+type PauseWaiter struct {
+	WorkflowID string `json:"workflowID"`
+	RunID      string `json:"runID"`
+	Scope      string `json:"scope"`
+	Value      string `json:"value"`
+}
+
+// ProcessingControlState represents the state of the processing control workflow, carried across continue-as-new runs
+type ProcessingControlState struct {
+	Paused  []PausedScope `json:"paused,omitempty"`
+	Waiters []PauseWaiter `json:"waiters,omitempty"`
+}
+
+// Money represents an amount in the minor units of its currency, e.g. 1550 MYR is MYR 15.50
+type Money struct {
+	Minor    int64  `json:"minor"`
+	Currency string `json:"currency"` // ISO 4217 code
+}
+
+// Recurring payment contract status constants
+const (
+	ContractActive     = "active"
+	ContractDelinquent = "delinquent"
+)
+
+// RecurringPaymentContract represents the terms of a recurring payment, passed to every payment workflow
+// started by its schedule
+type RecurringPaymentContract struct {
+	ConsentID       string            `json:"consentID"`
+	CustomerID      string            `json:"customerID,omitempty"`
+	BusinessUnit    string            `json:"businessUnit,omitempty"`
+	Amount          Money             `json:"amount"`
+	Terms           int               `json:"terms,omitempty"` // 0 means infinite
+	Start           time.Time         `json:"start,omitempty"`
+	End             time.Time         `json:"end,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	DunningSchedule []time.Duration   `json:"dunningSchedule,omitempty"` // retries of a failed charge, after the failure
+	Status          string            `json:"status,omitempty"`          // empty while active
+	StatusReason    string            `json:"statusReason,omitempty"`
+}
+
+// UnmarshalJSON also accepts the bare consent ID that schedules created before contracts were introduced
+// pass to their payment workflows; such a contract has no amount
+func (c *RecurringPaymentContract) UnmarshalJSON(data []byte) error {
+	var consentID string
+	if err := json.Unmarshal(data, &consentID); err == nil {
+		*c = RecurringPaymentContract{ConsentID: consentID}
+		return nil
+	}
+
+	type contract RecurringPaymentContract
+	return json.Unmarshal(data, (*contract)(c))
+}
+
+// ChargeTerm identifies the term of a recurring payment being charged. The idempotency key is derived from the
+// consent ID and the time the schedule started the payment for, so retries of the same term share it
+type ChargeTerm struct {
+	IdempotencyKey string    `json:"idempotencyKey"`
+	ScheduledAt    time.Time `json:"scheduledAt"`
+}
+
+// ChargeRecord represents a recurring charge taken, recorded once per idempotency key by the charge ledger
+type ChargeRecord struct {
+	IdempotencyKey string    `json:"idempotencyKey"` // consent ID and scheduled time of the term
+	ConsentID      string    `json:"consentID"`
+	Amount         Money     `json:"amount"`
+	ScheduledAt    time.Time `json:"scheduledAt"`
+	WorkflowID     string    `json:"workflowID"`
+	RunID          string    `json:"runID"`
+	Attempt        int32     `json:"attempt"`
+	Result         string    `json:"result"`
+	ChargedAt      time.Time `json:"chargedAt"`
+}
+
+// Dunning notice stage constants
+const (
+	DunningPaymentFailed = "payment-failed"
+	DunningRetryFailed   = "retry-failed"
+	DunningRecovered     = "recovered"
+	DunningDelinquent    = "delinquent"
+)
+
+// DunningRequest represents a failed recurring charge to be retried by the dunning workflow
+type DunningRequest struct {
+	Contract      RecurringPaymentContract `json:"contract"`
+	Term          ChargeTerm               `json:"term"`
+	PaymentID     string                   `json:"paymentID"` // workflow ID of the failed payment
+	FailedAt      time.Time                `json:"failedAt"`
+	FailureReason string                   `json:"failureReason"`
+}
+
+// DunningNotice represents a message to the customer about a failed recurring payment, sent as a webhook
+type DunningNotice struct {
+	NoticeID     string     `json:"noticeID"`
+	ConsentID    string     `json:"consentID"`
+	CustomerID   string     `json:"customerID,omitempty"`
+	BusinessUnit string     `json:"businessUnit,omitempty"`
+	PaymentID    string     `json:"paymentID"`
+	Amount       Money      `json:"amount"`
+	Stage        string     `json:"stage"`
+	Retries      int        `json:"retries"` // retries made so far
+	Reason       string     `json:"reason,omitempty"`
+	NextRetryAt  *time.Time `json:"nextRetryAt,omitempty"`
+	SentAt       time.Time  `json:"sentAt"`
 }
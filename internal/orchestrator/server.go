@@ -0,0 +1,167 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/orders"
+	orchestratorv1 "temporal-playground/proto/orchestrator/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the OrderOrchestrator gRPC service on top of the order service
+type Server struct {
+	orchestratorv1.UnimplementedOrderOrchestratorServer
+	orderService *orders.Service
+}
+
+func NewServer(orderService *orders.Service) *Server {
+	return &Server{
+		orderService: orderService,
+	}
+}
+
+func (s *Server) SubmitOrder(ctx context.Context, request *orchestratorv1.SubmitOrderRequest) (*orchestratorv1.SubmitOrderResponse, error) {
+	submit := orders.SubmitOrderRequest{
+		OrderID:      request.GetOrderId(),
+		Environment:  valueOrDefault(request.GetEnvironment(), "development"),
+		BusinessUnit: valueOrDefault(request.GetBusinessUnit(), "retail"),
+		Priority:     valueOrDefault(request.GetPriority(), "normal"),
+		Lifecycle:    request.GetLifecycle(),
+	}
+
+	result, err := s.orderService.SubmitOrder(ctx, submit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &orchestratorv1.SubmitOrderResponse{
+		OrderId:    result.OrderID,
+		WorkflowId: result.WorkflowID,
+		RunId:      result.RunID,
+		Duplicate:  result.Duplicate,
+	}, nil
+}
+
+func (s *Server) GetOrderStatus(ctx context.Context, request *orchestratorv1.GetOrderStatusRequest) (*orchestratorv1.GetOrderStatusResponse, error) {
+	orderStatus, err := s.orderService.GetOrderStatus(ctx, request.GetOrderId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &orchestratorv1.GetOrderStatusResponse{OrderId: orderStatus.OrderID}
+	for _, orderWorkflow := range orderStatus.Workflows {
+		workflow := &orchestratorv1.OrderWorkflow{
+			WorkflowId:   orderWorkflow.WorkflowID,
+			RunId:        orderWorkflow.RunID,
+			WorkflowType: orderWorkflow.WorkflowType,
+			Status:       orderWorkflow.Status,
+			Stage:        orderWorkflow.Stage,
+			StartTime:    timestamppb.New(orderWorkflow.StartTime),
+		}
+		if orderWorkflow.CloseTime != nil {
+			workflow.CloseTime = timestamppb.New(*orderWorkflow.CloseTime)
+		}
+		response.Workflows = append(response.Workflows, workflow)
+	}
+	return response, nil
+}
+
+func (s *Server) ResolveManual(ctx context.Context, request *orchestratorv1.ResolveManualRequest) (*orchestratorv1.ResolveManualResponse, error) {
+	result, err := s.orderService.ResolveOrder(ctx, orders.ResolveOrderRequest{
+		OrderID:    request.GetOrderId(),
+		Resolution: request.GetResolution(),
+		Operator:   request.GetOperator(),
+		Comment:    request.GetComment(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &orchestratorv1.ResolveManualResponse{
+		OrderId:    result.OrderID,
+		WorkflowId: result.WorkflowID,
+		Resolution: result.Resolution,
+	}, nil
+}
+
+func (s *Server) CreateRecurringPayment(ctx context.Context, request *orchestratorv1.CreateRecurringPaymentRequest) (*orchestratorv1.CreateRecurringPaymentResponse, error) {
+	paymentRequest := orders.CreateRecurringPaymentRequest{
+		ConsentID:    request.GetConsentId(),
+		CustomerID:   request.GetCustomerId(),
+		Amount:       models.Money{Minor: request.GetAmountMinor(), Currency: request.GetCurrency()},
+		Metadata:     request.GetMetadata(),
+		Terms:        int(request.GetTerms()),
+		Environment:  valueOrDefault(request.GetEnvironment(), "development"),
+		BusinessUnit: valueOrDefault(request.GetBusinessUnit(), "retail"),
+		Priority:     valueOrDefault(request.GetPriority(), "normal"),
+	}
+	err := orders.ScheduleOptions{
+		Interval:       request.GetInterval(),
+		Cron:           request.GetCron(),
+		DaysOfMonth:    request.GetDaysOfMonth(),
+		Weekdays:       request.GetWeekdays(),
+		YearlyDates:    request.GetYearlyDates(),
+		TimeOfDay:      request.GetTimeOfDay(),
+		TimeZone:       request.GetTimeZone(),
+		Start:          request.GetStart(),
+		End:            request.GetEnd(),
+		Jitter:         request.GetJitter(),
+		SkipDates:      request.GetSkipDates(),
+		Dunning:        request.GetDunning(),
+		Overlap:        request.GetOverlap(),
+		CatchupWindow:  request.GetCatchupWindow(),
+		PauseOnFailure: request.GetPauseOnFailure(),
+	}.Apply(&paymentRequest)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	payment, err := s.orderService.CreateRecurringPayment(ctx, paymentRequest)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &orchestratorv1.CreateRecurringPaymentResponse{
+		ConsentId:      payment.ConsentID,
+		LimitedTerms:   payment.LimitedTerms,
+		RemainingTerms: int32(payment.RemainingTerms),
+	}
+	for _, next := range payment.NextPaymentTimes {
+		response.NextPaymentTimes = append(response.NextPaymentTimes, timestamppb.New(next))
+	}
+	return response, nil
+}
+
+func (s *Server) CancelRecurringPayment(ctx context.Context, request *orchestratorv1.CancelRecurringPaymentRequest) (*orchestratorv1.CancelRecurringPaymentResponse, error) {
+	if err := s.orderService.CancelRecurringPayment(ctx, request.GetConsentId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &orchestratorv1.CancelRecurringPaymentResponse{}, nil
+}
+
+// toStatus maps order service errors to gRPC status codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, orders.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, orders.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, orders.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
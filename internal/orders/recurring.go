@@ -0,0 +1,592 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	recurringPaymentWorkflowType = "RegisterRecurringPayment"
+)
+
+const (
+	// DefaultPaymentOverlap keeps a term that is due while the last payment still runs until that payment
+	// completes, instead of charging one consent concurrently
+	DefaultPaymentOverlap = enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE
+	// DefaultPaymentCatchupWindow takes payments missed during an outage of up to a day; older ones are left to backfill
+	DefaultPaymentCatchupWindow = 24 * time.Hour
+)
+
+// CreateRecurringPaymentRequest represents a new recurring payment contract
+type CreateRecurringPaymentRequest struct {
+	ConsentID      string
+	CustomerID     string
+	Amount         models.Money
+	Metadata       map[string]string
+	Dunning        []time.Duration // retries of a failed charge, after the failure; defaults to 1, 3 and 7 days
+	Terms          int             // 0 means infinite
+	Interval       time.Duration
+	Cron           string         // standard 5 field cron expression
+	DaysOfMonth    []int          // 1-31; days past the end of a month fall on its last day
+	Weekdays       []time.Weekday // weekly payments
+	YearlyDates    []string       // MM-DD
+	TimeOfDay      string         // HH:MM of calendar payments, defaults to midnight
+	TimeZone       string
+	Start          time.Time // no payments before, optional
+	End            time.Time // no payments after, optional
+	Jitter         time.Duration
+	SkipDates      []string                      // YYYY-MM-DD, e.g. public holidays
+	Overlap        enumspb.ScheduleOverlapPolicy // defaults to DefaultPaymentOverlap
+	CatchupWindow  time.Duration                 // defaults to DefaultPaymentCatchupWindow
+	PauseOnFailure bool                          // pause the schedule when a payment fails, instead of leaving it to dunning
+	Environment    string
+	BusinessUnit   string
+	Priority       string
+}
+
+// RecurringPayment represents the state of a recurring payment schedule
+type RecurringPayment struct {
+	ConsentID        string                           `json:"consentID"`
+	Paused           bool                             `json:"paused"`
+	Note             string                           `json:"note,omitempty"`
+	Interval         string                           `json:"interval,omitempty"`
+	LimitedTerms     bool                             `json:"limitedTerms"`
+	RemainingTerms   int                              `json:"remainingTerms,omitempty"`
+	PaymentsMade     int                              `json:"paymentsMade,omitempty"`
+	NextPaymentTimes []time.Time                      `json:"nextPaymentTimes"`
+	Jitter           time.Duration                    `json:"jitter,omitempty"`
+	Overlap          string                           `json:"overlap,omitempty"`
+	CatchupWindow    time.Duration                    `json:"catchupWindow,omitempty"`
+	PauseOnFailure   bool                             `json:"pauseOnFailure"`
+	Priority         string                           `json:"priority,omitempty"`
+	RecentPayments   []RecurringPaymentResult         `json:"recentPayments,omitempty"`
+	Contract         *models.RecurringPaymentContract `json:"contract,omitempty"`
+}
+
+// RecurringPaymentResult represents one payment workflow started by a recurring payment schedule
+type RecurringPaymentResult struct {
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	WorkflowID  string    `json:"workflowID,omitempty"`
+	RunID       string    `json:"runID,omitempty"`
+	Status      string    `json:"status,omitempty"`
+}
+
+// UpdateRecurringPaymentRequest represents a change to a recurring payment schedule; nil fields are left unchanged
+type UpdateRecurringPaymentRequest struct {
+	Terms    *int           // 0 means infinite
+	Interval *time.Duration // replaces every interval of the schedule
+}
+
+// CreateRecurringPayment schedules the recurring payment workflow for a consent
+func (s *Service) CreateRecurringPayment(ctx context.Context, request CreateRecurringPaymentRequest) (RecurringPayment, error) {
+	if err := ValidateID("consent", request.ConsentID); err != nil {
+		return RecurringPayment{}, err
+	}
+	spec, err := request.validate()
+	if err != nil {
+		return RecurringPayment{}, err
+	}
+
+	overlap, catchupWindow := request.policies()
+	handle, err := s.workflowManager.StartScheduledWorkflow(ctx, temporal.ScheduleWorkflowOptions{
+		RemainingActions: request.Terms,
+		Specs:            spec,
+		Overlap:          overlap,
+		CatchupWindow:    catchupWindow,
+		PauseOnFailure:   request.PauseOnFailure,
+		Jitter:           request.Jitter,
+		StartWorkflowOptions: temporal.StartWorkflowOptions{
+			WorkflowID:   request.ConsentID,
+			TaskQueue:    s.config.RecurringScheduleQueue,
+			OrderID:      request.ConsentID,
+			Environment:  request.Environment,
+			BusinessUnit: request.BusinessUnit,
+			Priority:     request.Priority,
+		},
+	}, workflows.RegisterRecurringPayment, request.contract())
+	if err != nil {
+		if temporal.IsAlreadyStarted(err) {
+			return RecurringPayment{}, fmt.Errorf("%w: recurring payment %s already exists", ErrConflict, request.ConsentID)
+		}
+		return RecurringPayment{}, err
+	}
+
+	log.Printf("Scheduled recurring payment %s", handle.GetID())
+	return s.GetRecurringPayment(ctx, handle.GetID())
+}
+
+// validate checks a request before it is scheduled, returning its schedule spec
+func (r CreateRecurringPaymentRequest) validate() (client.ScheduleSpec, error) {
+	if r.Terms < 0 {
+		return client.ScheduleSpec{}, fmt.Errorf("%w: terms must not be negative", ErrInvalidArgument)
+	}
+	if err := r.Amount.Validate(); err != nil {
+		return client.ScheduleSpec{}, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	if err := validateDunning(r.Dunning); err != nil {
+		return client.ScheduleSpec{}, err
+	}
+	if err := validatePriority(r.Priority); err != nil {
+		return client.ScheduleSpec{}, err
+	}
+	if r.CatchupWindow < 0 {
+		return client.ScheduleSpec{}, fmt.Errorf("%w: catch-up window must not be negative", ErrInvalidArgument)
+	}
+	if _, ok := enumspb.ScheduleOverlapPolicy_name[int32(r.Overlap)]; !ok {
+		return client.ScheduleSpec{}, fmt.Errorf("%w: unknown overlap policy %d", ErrInvalidArgument, r.Overlap)
+	}
+	spec, err := r.ScheduleSpec()
+	if err != nil {
+		return spec, err
+	}
+	if times, err := previewSchedule(spec, time.Now(), 1); err != nil || len(times) == 0 {
+		return client.ScheduleSpec{}, fmt.Errorf("%w: the schedule never takes a payment", ErrInvalidArgument)
+	}
+	return spec, nil
+}
+
+// contract is the recurring payment contract of the request, passed to every payment workflow
+func (r CreateRecurringPaymentRequest) contract() models.RecurringPaymentContract {
+	return models.RecurringPaymentContract{
+		ConsentID:       r.ConsentID,
+		CustomerID:      r.CustomerID,
+		BusinessUnit:    r.BusinessUnit,
+		Amount:          r.Amount,
+		Terms:           r.Terms,
+		Start:           r.Start,
+		End:             r.End,
+		Metadata:        r.Metadata,
+		DunningSchedule: r.Dunning,
+	}
+}
+
+// policies returns the overlap policy and catch-up window of the request's schedule, with their defaults
+func (r CreateRecurringPaymentRequest) policies() (enumspb.ScheduleOverlapPolicy, time.Duration) {
+	overlap, catchupWindow := r.Overlap, r.CatchupWindow
+	if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+		overlap = DefaultPaymentOverlap
+	}
+	if catchupWindow == 0 {
+		catchupWindow = DefaultPaymentCatchupWindow
+	}
+	return overlap, catchupWindow
+}
+
+// remainingTerms returns the terms a schedule still has to take after paymentsMade payments; terms of 0 means infinite
+func remainingTerms(terms int, paymentsMade int) (limited bool, remaining int) {
+	if terms == 0 {
+		return false, 0
+	}
+	return true, max(terms-paymentsMade, 0)
+}
+
+// scheduleContract decodes the recurring payment contract a schedule passes to its payment workflows
+func scheduleContract(consentID string, action client.ScheduleAction) (models.RecurringPaymentContract, error) {
+	var contract models.RecurringPaymentContract
+	workflowAction, ok := action.(*client.ScheduleWorkflowAction)
+	if !ok || len(workflowAction.Args) == 0 {
+		return contract, fmt.Errorf("recurring payment %s has no contract", consentID)
+	}
+	payload, ok := workflowAction.Args[0].(*commonpb.Payload)
+	if !ok {
+		return contract, fmt.Errorf("recurring payment %s has no encoded contract", consentID)
+	}
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &contract); err != nil {
+		return contract, fmt.Errorf("failed to decode the contract of recurring payment %s: %w", consentID, err)
+	}
+	return contract, nil
+}
+
+// ListRecurringPayments lists the recurring payment schedules
+func (s *Service) ListRecurringPayments(ctx context.Context) ([]RecurringPayment, error) {
+	schedules, err := s.workflowManager.ListSchedules(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	payments := []RecurringPayment{}
+	for _, schedule := range schedules {
+		if schedule.WorkflowType.Name != recurringPaymentWorkflowType {
+			continue
+		}
+		payments = append(payments, RecurringPayment{
+			ConsentID:        schedule.ID,
+			Paused:           schedule.Paused,
+			Note:             schedule.Note,
+			Interval:         scheduleInterval(schedule.Spec),
+			NextPaymentTimes: schedule.NextActionTimes,
+		})
+	}
+	return payments, nil
+}
+
+// GetRecurringPayment describes a recurring payment schedule, including the status of its recent payments
+func (s *Service) GetRecurringPayment(ctx context.Context, consentID string) (RecurringPayment, error) {
+	description, err := s.workflowManager.GetScheduleHandle(ctx, consentID).Describe(ctx)
+	if err != nil {
+		return RecurringPayment{}, scheduleError(err, consentID)
+	}
+
+	state := description.Schedule.State
+	payment := RecurringPayment{
+		ConsentID:        consentID,
+		Paused:           state.Paused,
+		Note:             state.Note,
+		Interval:         scheduleInterval(description.Schedule.Spec),
+		LimitedTerms:     state.LimitedActions,
+		RemainingTerms:   state.RemainingActions,
+		PaymentsMade:     description.Info.NumActions,
+		NextPaymentTimes: description.Info.NextActionTimes,
+	}
+	if spec := description.Schedule.Spec; spec != nil {
+		payment.Jitter = spec.Jitter
+	}
+	if policy := description.Schedule.Policy; policy != nil {
+		payment.Overlap = strings.TrimPrefix(policy.Overlap.String(), "SCHEDULE_OVERLAP_POLICY_")
+		payment.CatchupWindow = policy.CatchupWindow
+		payment.PauseOnFailure = policy.PauseOnFailure
+	}
+
+	if contract, err := scheduleContract(consentID, description.Schedule.Action); err == nil {
+		payment.Contract = &contract
+	}
+	if action, ok := description.Schedule.Action.(*client.ScheduleWorkflowAction); ok {
+		if payload, ok := action.Memo["priority"].(*commonpb.Payload); ok {
+			_ = converter.GetDefaultDataConverter().FromPayload(payload, &payment.Priority)
+		}
+	}
+
+	for _, action := range description.Info.RecentActions {
+		result := RecurringPaymentResult{
+			ScheduledAt: action.ScheduleTime,
+			StartedAt:   action.ActualTime,
+		}
+		if execution := action.StartWorkflowResult; execution != nil {
+			result.WorkflowID, result.RunID = execution.WorkflowID, execution.FirstExecutionRunID
+			if workflowDescription, err := s.workflowManager.DescribeWorkflow(ctx, execution.WorkflowID, execution.FirstExecutionRunID); err == nil {
+				result.Status = workflowDescription.GetWorkflowExecutionInfo().GetStatus().String()
+			}
+		}
+		payment.RecentPayments = append(payment.RecentPayments, result)
+	}
+	return payment, nil
+}
+
+// UpdateRecurringPaymentTerms changes the number of remaining terms, 0 means infinite
+func (s *Service) UpdateRecurringPaymentTerms(ctx context.Context, consentID string, terms int) (RecurringPayment, error) {
+	return s.UpdateRecurringPayment(ctx, consentID, UpdateRecurringPaymentRequest{Terms: &terms})
+}
+
+// UpdateRecurringPayment changes the remaining terms and/or the interval of a recurring payment. A new interval
+// replaces the calendar or cron schedule of a recurring payment
+func (s *Service) UpdateRecurringPayment(ctx context.Context, consentID string, request UpdateRecurringPaymentRequest) (RecurringPayment, error) {
+	if request.Terms == nil && request.Interval == nil {
+		return RecurringPayment{}, fmt.Errorf("%w: nothing to update", ErrInvalidArgument)
+	}
+	if request.Terms != nil && *request.Terms < 0 {
+		return RecurringPayment{}, fmt.Errorf("%w: terms must not be negative", ErrInvalidArgument)
+	}
+	if request.Interval != nil && *request.Interval < time.Second {
+		return RecurringPayment{}, fmt.Errorf("%w: interval must be at least 1s", ErrInvalidArgument)
+	}
+
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			if request.Terms != nil {
+				schedule.State.LimitedActions = *request.Terms > 0
+				schedule.State.RemainingActions = *request.Terms
+			}
+			if request.Interval != nil {
+				// the interval replaces the calendar or cron the schedule ran on, or both would take payments
+				schedule.Spec.Intervals = []client.ScheduleIntervalSpec{{Every: *request.Interval}}
+				schedule.Spec.Calendars = nil
+				schedule.Spec.CronExpressions = nil
+			}
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return RecurringPayment{}, scheduleError(err, consentID)
+	}
+
+	log.Printf("Updated recurring payment %s", consentID)
+	return s.GetRecurringPayment(ctx, consentID)
+}
+
+// RecurringPaymentChanges lists how a recurring payment differs from the request it should have been created with:
+// its contract, remaining terms, policies, priority, jitter and schedule. The remaining terms are the request's
+// terms less the payments made. The server does not return the spec as it was sent, e.g. cron expressions come back
+// as calendars, so the schedules are compared by their next payment times after from
+func RecurringPaymentChanges(payment RecurringPayment, request CreateRecurringPaymentRequest, from time.Time) ([]string, error) {
+	spec, err := request.ScheduleSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	changed := func(format string, args ...any) {
+		changes = append(changes, fmt.Sprintf(format, args...))
+	}
+
+	wantedContract := request.contract()
+	if current := payment.Contract; current == nil {
+		changed("contract")
+	} else {
+		if current.Amount != wantedContract.Amount {
+			changed("amount %s -> %s", current.Amount, wantedContract.Amount)
+		}
+		if current.CustomerID != wantedContract.CustomerID {
+			changed("customer %q -> %q", current.CustomerID, wantedContract.CustomerID)
+		}
+		if current.BusinessUnit != wantedContract.BusinessUnit {
+			changed("business unit %q -> %q", current.BusinessUnit, wantedContract.BusinessUnit)
+		}
+		if current.Terms != wantedContract.Terms {
+			changed("terms %d -> %d", current.Terms, wantedContract.Terms)
+		}
+		if !current.Start.Equal(wantedContract.Start) {
+			changed("start")
+		}
+		if !current.End.Equal(wantedContract.End) {
+			changed("end")
+		}
+		if !maps.Equal(current.Metadata, wantedContract.Metadata) {
+			changed("metadata")
+		}
+		if !slices.Equal(current.DunningSchedule, wantedContract.DunningSchedule) {
+			changed("dunning")
+		}
+	}
+
+	limited, remaining := remainingTerms(request.Terms, payment.PaymentsMade)
+	if payment.LimitedTerms != limited || payment.RemainingTerms != remaining {
+		changed("remaining terms %s -> %s", describeTerms(payment.LimitedTerms, payment.RemainingTerms), describeTerms(limited, remaining))
+	}
+
+	overlap, catchupWindow := request.policies()
+	if wanted := strings.TrimPrefix(overlap.String(), "SCHEDULE_OVERLAP_POLICY_"); payment.Overlap != wanted {
+		changed("overlap %s -> %s", payment.Overlap, wanted)
+	}
+	if payment.CatchupWindow != catchupWindow {
+		changed("catch-up window %s -> %s", payment.CatchupWindow, catchupWindow)
+	}
+	if payment.PauseOnFailure != request.PauseOnFailure {
+		changed("pause on failure %t -> %t", payment.PauseOnFailure, request.PauseOnFailure)
+	}
+	if payment.Priority != request.Priority {
+		changed("priority %q -> %q", payment.Priority, request.Priority)
+	}
+
+	if payment.Jitter != request.Jitter {
+		changed("jitter %s -> %s", payment.Jitter, request.Jitter)
+	}
+	// the server delays each of its next payment times by up to the jitter
+	wanted, err := previewSchedule(spec, from, len(payment.NextPaymentTimes))
+	if err != nil {
+		return nil, err
+	}
+	sameTimes := len(wanted) == len(payment.NextPaymentTimes)
+	for i := 0; sameTimes && i < len(wanted); i++ {
+		delay := payment.NextPaymentTimes[i].Sub(wanted[i])
+		sameTimes = delay >= 0 && delay <= payment.Jitter
+	}
+	if !sameTimes {
+		changed("schedule")
+	}
+	return changes, nil
+}
+
+// describeTerms describes the remaining terms of a schedule, e.g. "3" or "infinite"
+func describeTerms(limited bool, remaining int) string {
+	if !limited {
+		return "infinite"
+	}
+	return fmt.Sprint(remaining)
+}
+
+// ReplaceRecurringPayment changes a recurring payment to the request: its schedule, policies, remaining terms,
+// priority and contract. The remaining terms are the request's terms less the payments made. Whether the schedule
+// is paused, the contract status and the environment the schedule was created in are kept
+func (s *Service) ReplaceRecurringPayment(ctx context.Context, request CreateRecurringPaymentRequest) (RecurringPayment, error) {
+	spec, err := request.validate()
+	if err != nil {
+		return RecurringPayment{}, err
+	}
+	spec.Jitter = request.Jitter
+	overlap, catchupWindow := request.policies()
+
+	err = s.workflowManager.GetScheduleHandle(ctx, request.ConsentID).Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			current, err := scheduleContract(request.ConsentID, schedule.Action)
+			if err != nil {
+				return nil, err
+			}
+
+			schedule.Spec = &spec
+			if schedule.Policy == nil {
+				schedule.Policy = &client.SchedulePolicies{}
+			}
+			schedule.Policy.Overlap = overlap
+			schedule.Policy.CatchupWindow = catchupWindow
+			schedule.Policy.PauseOnFailure = request.PauseOnFailure
+			if schedule.State == nil {
+				schedule.State = &client.ScheduleState{}
+			}
+			schedule.State.LimitedActions, schedule.State.RemainingActions = remainingTerms(request.Terms, input.Description.Info.NumActions)
+
+			contract := request.contract()
+			contract.Status, contract.StatusReason = current.Status, current.StatusReason
+			action := schedule.Action.(*client.ScheduleWorkflowAction)
+			action.Args[0] = contract
+			if action.Memo == nil {
+				action.Memo = map[string]any{}
+			}
+			action.Memo["businessUnit"] = request.BusinessUnit
+			action.Memo["priority"] = request.Priority
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return RecurringPayment{}, scheduleError(err, request.ConsentID)
+	}
+
+	log.Printf("Replaced recurring payment %s", request.ConsentID)
+	return s.GetRecurringPayment(ctx, request.ConsentID)
+}
+
+// PauseRecurringPayment stops a recurring payment from taking payments until it is unpaused
+func (s *Service) PauseRecurringPayment(ctx context.Context, consentID string, note string) error {
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Pause(ctx, client.SchedulePauseOptions{Note: note})
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Paused recurring payment %s", consentID)
+	return nil
+}
+
+// UnpauseRecurringPayment resumes a paused recurring payment; payments missed while paused are not taken
+func (s *Service) UnpauseRecurringPayment(ctx context.Context, consentID string, note string) error {
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Unpause(ctx, client.ScheduleUnpauseOptions{Note: note})
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Unpaused recurring payment %s", consentID)
+	return nil
+}
+
+// MarkRecurringPaymentDelinquent pauses a recurring payment whose dunning failed and marks its contract delinquent,
+// so no further terms are charged until an operator unpauses it
+func (s *Service) MarkRecurringPaymentDelinquent(ctx context.Context, consentID string, reason string) error {
+	note := "delinquent: " + reason
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.State.Paused = true
+			schedule.State.Note = note
+
+			if action, ok := schedule.Action.(*client.ScheduleWorkflowAction); ok && len(action.Args) > 0 {
+				if payload, ok := action.Args[0].(*commonpb.Payload); ok {
+					var contract models.RecurringPaymentContract
+					if err := converter.GetDefaultDataConverter().FromPayload(payload, &contract); err != nil {
+						return nil, err
+					}
+					contract.Status = models.ContractDelinquent
+					contract.StatusReason = reason
+					action.Args[0] = contract
+				}
+			}
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Marked recurring payment %s delinquent: %s", consentID, reason)
+	return nil
+}
+
+// TriggerRecurringPayment takes a payment now, outside of the schedule
+func (s *Service) TriggerRecurringPayment(ctx context.Context, consentID string) error {
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Trigger(ctx, client.ScheduleTriggerOptions{})
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Triggered recurring payment %s", consentID)
+	return nil
+}
+
+// BackfillRecurringPayment takes the payments the schedule would have taken between from and to,
+// e.g. after an outage or while it was paused. The payments are taken one after another
+func (s *Service) BackfillRecurringPayment(ctx context.Context, consentID string, from time.Time, to time.Time) error {
+	if !from.Before(to) {
+		return fmt.Errorf("%w: backfill start must be before its end", ErrInvalidArgument)
+	}
+
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Backfill(ctx, client.ScheduleBackfillOptions{
+		Backfill: []client.ScheduleBackfill{
+			{
+				Start:   from,
+				End:     to,
+				Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL, // the schedule's policy would drop all but one missed term
+			},
+		},
+	})
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Backfilled recurring payment %s from %s to %s", consentID, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	return nil
+}
+
+// CancelRecurringPayment deletes a recurring payment schedule
+func (s *Service) CancelRecurringPayment(ctx context.Context, consentID string) error {
+	err := s.workflowManager.GetScheduleHandle(ctx, consentID).Delete(ctx)
+	if err != nil {
+		return scheduleError(err, consentID)
+	}
+
+	log.Printf("Cancelled recurring payment %s", consentID)
+	return nil
+}
+
+// scheduleError reports a missing schedule as ErrNotFound
+func scheduleError(err error, consentID string) error {
+	if temporal.IsNotFound(err) {
+		return fmt.Errorf("%w: recurring payment %s", ErrNotFound, consentID)
+	}
+	return err
+}
+
+// scheduleInterval describes the intervals of a schedule spec, e.g. "1m0s"
+func scheduleInterval(spec *client.ScheduleSpec) string {
+	if spec == nil {
+		return ""
+	}
+
+	intervals := make([]string, 0, len(spec.Intervals))
+	for _, interval := range spec.Intervals {
+		intervals = append(intervals, interval.Every.String())
+	}
+	return strings.Join(intervals, ", ")
+}
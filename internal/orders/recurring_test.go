@@ -0,0 +1,73 @@
+package orders
+
+import (
+	"slices"
+	"temporal-playground/internal/models"
+	"testing"
+	"time"
+)
+
+func TestRecurringPaymentChanges(t *testing.T) {
+	from := mustParseTime(t, "2025-01-01T00:00:00Z")
+	request := CreateRecurringPaymentRequest{
+		ConsentID:      "gym-42",
+		CustomerID:     "cust-1",
+		Amount:         models.Money{Minor: 4990, Currency: "MYR"},
+		Metadata:       map[string]string{"plan": "gold"},
+		Dunning:        []time.Duration{24 * time.Hour},
+		Terms:          12,
+		Interval:       24 * time.Hour,
+		PauseOnFailure: true,
+		BusinessUnit:   "retail",
+		Priority:       "normal",
+	}
+	contract := request.contract()
+	payment := RecurringPayment{
+		ConsentID:        "gym-42",
+		LimitedTerms:     true,
+		RemainingTerms:   10,
+		PaymentsMade:     2,
+		NextPaymentTimes: []time.Time{mustParseTime(t, "2025-01-02T00:00:00Z"), mustParseTime(t, "2025-01-03T00:00:00Z")},
+		Overlap:          "BufferOne",
+		CatchupWindow:    DefaultPaymentCatchupWindow,
+		PauseOnFailure:   true,
+		Priority:         "normal",
+		Contract:         &contract,
+	}
+
+	changes, err := RecurringPaymentChanges(payment, request, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("unchanged payment has changes %v", changes)
+	}
+
+	changed := request
+	changed.CustomerID = "cust-2"
+	changed.Metadata = map[string]string{"plan": "silver"}
+	changed.Dunning = []time.Duration{24 * time.Hour, 72 * time.Hour}
+	changed.Terms = 24
+	changed.CatchupWindow = time.Hour
+	changed.PauseOnFailure = false
+	changed.BusinessUnit = "corporate"
+	changed.Priority = "high"
+	changes, err = RecurringPaymentChanges(payment, changed, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`customer "cust-1" -> "cust-2"`,
+		`business unit "retail" -> "corporate"`,
+		"terms 12 -> 24",
+		"metadata",
+		"dunning",
+		"remaining terms 10 -> 22",
+		"catch-up window 24h0m0s -> 1h0m0s",
+		"pause on failure true -> false",
+		`priority "normal" -> "high"`,
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("changes = %q, want %q", changes, want)
+	}
+}
@@ -0,0 +1,543 @@
+package orders
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+const (
+	previewHorizon = 10 * 366 * 24 * time.Hour // how far ahead the preview looks for run times
+	lastYear       = 2099                      // last year covered by the non-leap year ranges
+)
+
+// nonLeapYears matches the years without a 29 February, used to take end-of-month payments on 28 February
+var nonLeapYears = []client.ScheduleRange{
+	{Start: 2001, End: lastYear, Step: 4},
+	{Start: 2002, End: lastYear, Step: 4},
+	{Start: 2003, End: lastYear, Step: 4},
+}
+
+// ScheduleSpec translates the schedule of the request into a Temporal schedule spec. Exactly one of
+// Interval, Cron, DaysOfMonth, Weekdays or YearlyDates describes when payments are taken
+func (r CreateRecurringPaymentRequest) ScheduleSpec() (client.ScheduleSpec, error) {
+	spec := client.ScheduleSpec{
+		TimeZoneName: r.TimeZone,
+		StartAt:      r.Start,
+		EndAt:        r.End,
+	}
+
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return spec, fmt.Errorf("%w: unknown time zone %q", ErrInvalidArgument, r.TimeZone)
+	}
+	if !r.Start.IsZero() && !r.End.IsZero() && !r.Start.Before(r.End) {
+		return spec, fmt.Errorf("%w: start must be before end", ErrInvalidArgument)
+	}
+	if r.Jitter < 0 {
+		return spec, fmt.Errorf("%w: jitter must not be negative", ErrInvalidArgument)
+	}
+
+	kinds := 0
+	for _, set := range []bool{r.Interval != 0, r.Cron != "", len(r.DaysOfMonth) > 0, len(r.Weekdays) > 0, len(r.YearlyDates) > 0} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return spec, fmt.Errorf("%w: exactly one of interval, cron, days of month, weekdays or yearly dates is required", ErrInvalidArgument)
+	}
+	if r.Cron == "" && r.Interval == 0 {
+		if spec.Calendars, err = r.calendars(); err != nil {
+			return spec, err
+		}
+	} else if r.TimeOfDay != "" {
+		return spec, fmt.Errorf("%w: time of day only applies to days of month, weekdays and yearly dates", ErrInvalidArgument)
+	}
+
+	switch {
+	case r.Interval != 0:
+		if r.Interval < time.Second {
+			return spec, fmt.Errorf("%w: interval must be at least 1s", ErrInvalidArgument)
+		}
+		spec.Intervals = []client.ScheduleIntervalSpec{{Every: r.Interval}}
+	case r.Cron != "":
+		if _, err := cron.ParseStandard(r.Cron); err != nil {
+			return spec, fmt.Errorf("%w: invalid cron expression %q: %v", ErrInvalidArgument, r.Cron, err)
+		}
+		spec.CronExpressions = []string{r.Cron}
+	}
+
+	for _, skipDate := range r.SkipDates {
+		date, err := time.ParseInLocation(time.DateOnly, skipDate, location)
+		if err != nil {
+			return spec, fmt.Errorf("%w: invalid skip date %q, expected YYYY-MM-DD", ErrInvalidArgument, skipDate)
+		}
+		spec.Skip = append(spec.Skip, client.ScheduleCalendarSpec{
+			Second:     []client.ScheduleRange{{Start: 0, End: 59}},
+			Minute:     []client.ScheduleRange{{Start: 0, End: 59}},
+			Hour:       []client.ScheduleRange{{Start: 0, End: 23}},
+			DayOfMonth: []client.ScheduleRange{{Start: date.Day()}},
+			Month:      []client.ScheduleRange{{Start: int(date.Month())}},
+			Year:       []client.ScheduleRange{{Start: date.Year()}},
+			Comment:    "skip " + skipDate,
+		})
+	}
+	return spec, nil
+}
+
+// calendars builds the calendar specs of a monthly, weekly or yearly schedule. Days past the end of a
+// month fall on its last day, so day 31 is always the last day of the month
+func (r CreateRecurringPaymentRequest) calendars() ([]client.ScheduleCalendarSpec, error) {
+	hour, minute := 0, 0
+	if r.TimeOfDay != "" {
+		at, err := time.Parse("15:04", r.TimeOfDay)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid time of day %q, expected HH:MM", ErrInvalidArgument, r.TimeOfDay)
+		}
+		hour, minute = at.Hour(), at.Minute()
+	}
+	at := client.ScheduleCalendarSpec{
+		Second: []client.ScheduleRange{{Start: 0}},
+		Minute: []client.ScheduleRange{{Start: minute}},
+		Hour:   []client.ScheduleRange{{Start: hour}},
+	}
+
+	if len(r.Weekdays) > 0 {
+		weekly := at
+		for _, weekday := range r.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return nil, fmt.Errorf("%w: invalid weekday %d", ErrInvalidArgument, weekday)
+			}
+			weekly.DayOfWeek = append(weekly.DayOfWeek, client.ScheduleRange{Start: int(weekday)})
+		}
+		weekly.Comment = "weekly"
+		return []client.ScheduleCalendarSpec{weekly}, nil
+	}
+
+	// the days requested in each month, 1-12
+	monthDays := map[int][]int{}
+	for _, day := range r.DaysOfMonth {
+		if day < 1 || day > 31 {
+			return nil, fmt.Errorf("%w: day of month must be between 1 and 31, got %d", ErrInvalidArgument, day)
+		}
+		for month := 1; month <= 12; month++ {
+			monthDays[month] = append(monthDays[month], day)
+		}
+	}
+	for _, yearlyDate := range r.YearlyDates {
+		date, err := time.Parse("01-02", yearlyDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid yearly date %q, expected MM-DD", ErrInvalidArgument, yearlyDate)
+		}
+		monthDays[int(date.Month())] = append(monthDays[int(date.Month())], date.Day())
+	}
+
+	// group the months taking payments on the same days into one spec
+	var (
+		calendars []client.ScheduleCalendarSpec
+		groups    = map[string]int{}
+	)
+	for month := 1; month <= 12; month++ {
+		days, ok := monthDays[month]
+		if !ok {
+			continue
+		}
+
+		clamped, needsLeapFallback := clampDays(days, month)
+		if needsLeapFallback {
+			february := at
+			february.Month = []client.ScheduleRange{{Start: 2}}
+			february.DayOfMonth = []client.ScheduleRange{{Start: 28}}
+			february.Year = nonLeapYears
+			february.Comment = "last day of February in non-leap years"
+			calendars = append(calendars, february)
+		}
+
+		key := fmt.Sprint(clamped)
+		if i, ok := groups[key]; ok {
+			calendars[i].Month = append(calendars[i].Month, client.ScheduleRange{Start: month})
+			continue
+		}
+		calendar := at
+		calendar.Month = []client.ScheduleRange{{Start: month}}
+		for _, day := range clamped {
+			calendar.DayOfMonth = append(calendar.DayOfMonth, client.ScheduleRange{Start: day})
+		}
+		calendar.Comment = "days " + key
+		groups[key] = len(calendars)
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+// clampDays moves days past the end of the month onto its last day. February is clamped to the 29th,
+// which only exists in leap years, and reports that the 28th is needed in the other years
+func clampDays(days []int, month int) ([]int, bool) {
+	lastDay := time.Date(2001, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if month == 2 {
+		lastDay = 29
+	}
+
+	var clamped []int
+	for _, day := range days {
+		clamped = append(clamped, min(day, lastDay))
+	}
+	slices.Sort(clamped)
+	clamped = slices.Compact(clamped)
+
+	return clamped, month == 2 && slices.Contains(clamped, 29) && !slices.Contains(clamped, 28)
+}
+
+// PreviewRecurringPayment returns the next count payment times of the request's schedule after from,
+// without jitter. It errors when the schedule is invalid or never takes a payment
+func PreviewRecurringPayment(request CreateRecurringPaymentRequest, from time.Time, count int) ([]time.Time, error) {
+	spec, err := request.ScheduleSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := previewSchedule(spec, from, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("%w: the schedule never takes a payment", ErrInvalidArgument)
+	}
+	return times, nil
+}
+
+// previewSchedule evaluates a schedule spec locally, the same way the Temporal server does
+func previewSchedule(spec client.ScheduleSpec, from time.Time, count int) ([]time.Time, error) {
+	location, err := time.LoadLocation(spec.TimeZoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	after := from.In(location)
+	if !spec.StartAt.IsZero() && spec.StartAt.After(after) {
+		after = spec.StartAt.In(location).Add(-time.Nanosecond)
+	}
+	until := after.Add(previewHorizon)
+	if !spec.EndAt.IsZero() && spec.EndAt.Before(until) {
+		until = spec.EndAt.In(location)
+	}
+
+	// each source returns its first time after the given one, or the zero time when there is none
+	var sources []func(time.Time) time.Time
+	for _, interval := range spec.Intervals {
+		sources = append(sources, func(after time.Time) time.Time {
+			every, offset := int64(interval.Every), int64(interval.Offset)
+			periods := (after.UnixNano() - offset) / every
+			return time.Unix(0, (periods+1)*every+offset).In(location)
+		})
+	}
+	for _, expression := range spec.CronExpressions {
+		schedule, err := cron.ParseStandard(expression)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, schedule.Next)
+	}
+	for _, calendar := range spec.Calendars {
+		sources = append(sources, func(after time.Time) time.Time {
+			for day := dayStart(after); !day.After(until); day = day.AddDate(0, 0, 1) {
+				if times := calendarTimes(calendar, day, after, until); len(times) > 0 {
+					return times[0]
+				}
+			}
+			return time.Time{}
+		})
+	}
+
+	var times []time.Time
+	for len(times) < count {
+		var next time.Time
+		for _, source := range sources {
+			if t := source(after); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+		if next.IsZero() || next.After(until) {
+			break
+		}
+
+		after = next
+		if !slices.ContainsFunc(spec.Skip, func(skip client.ScheduleCalendarSpec) bool { return calendarMatches(skip, next) }) {
+			times = append(times, next)
+		}
+	}
+	return times, nil
+}
+
+// calendarTimes returns the times of the day matching the calendar, between from and until
+func calendarTimes(calendar client.ScheduleCalendarSpec, day time.Time, from time.Time, until time.Time) []time.Time {
+	if !matchesRanges(calendar.Year, day.Year(), true) ||
+		!matchesRanges(calendar.Month, int(day.Month()), true) ||
+		!matchesRanges(calendar.DayOfMonth, day.Day(), true) ||
+		!matchesRanges(calendar.DayOfWeek, int(day.Weekday()), true) {
+		return nil
+	}
+
+	var times []time.Time
+	for _, hour := range rangeValues(calendar.Hour, 23) {
+		for _, minute := range rangeValues(calendar.Minute, 59) {
+			for _, second := range rangeValues(calendar.Second, 59) {
+				at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location())
+				if at.After(from) && !at.After(until) {
+					times = append(times, at)
+				}
+			}
+		}
+	}
+	return times
+}
+
+// calendarMatches reports whether the time matches every field of the calendar
+func calendarMatches(calendar client.ScheduleCalendarSpec, t time.Time) bool {
+	return matchesRanges(calendar.Year, t.Year(), true) &&
+		matchesRanges(calendar.Month, int(t.Month()), true) &&
+		matchesRanges(calendar.DayOfMonth, t.Day(), true) &&
+		matchesRanges(calendar.DayOfWeek, int(t.Weekday()), true) &&
+		matchesRanges(calendar.Hour, t.Hour(), false) &&
+		matchesRanges(calendar.Minute, t.Minute(), false) &&
+		matchesRanges(calendar.Second, t.Second(), false)
+}
+
+// matchesRanges reports whether the value is in one of the ranges. Empty ranges match every value
+// for the date fields and only 0 for the time of day fields
+func matchesRanges(ranges []client.ScheduleRange, value int, emptyMatchesAll bool) bool {
+	if len(ranges) == 0 {
+		return emptyMatchesAll || value == 0
+	}
+	for _, r := range ranges {
+		end, step := max(r.End, r.Start), max(r.Step, 1)
+		if value >= r.Start && value <= end && (value-r.Start)%step == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func rangeValues(ranges []client.ScheduleRange, maxValue int) []int {
+	var values []int
+	for value := 0; value <= maxValue; value++ {
+		if matchesRanges(ranges, value, false) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// ScheduleOptions is the schedule of a recurring payment as text, the way create-recurring-payment and the
+// APIs take it: durations such as 720h, dates as YYYY-MM-DD or RFC3339, days of the month such as 1,15 or last
+// and weekdays such as mon,thu
+type ScheduleOptions struct {
+	Interval       string
+	Cron           string
+	DaysOfMonth    string
+	Weekdays       string
+	YearlyDates    []string
+	TimeOfDay      string
+	TimeZone       string // defaults to Asia/Kuala_Lumpur
+	Start          string
+	End            string
+	Jitter         string
+	SkipDates      []string
+	Dunning        string
+	Overlap        string
+	CatchupWindow  string
+	PauseOnFailure bool
+}
+
+// Apply parses the options into the schedule of a request. It does not default the schedule: exactly one of
+// interval, cron, days of month, weekdays or yearly dates is still required
+func (o ScheduleOptions) Apply(request *CreateRecurringPaymentRequest) error {
+	request.Cron = o.Cron
+	request.YearlyDates = o.YearlyDates
+	request.TimeOfDay = o.TimeOfDay
+	request.TimeZone = o.TimeZone
+	request.SkipDates = o.SkipDates
+	request.PauseOnFailure = o.PauseOnFailure
+	if request.TimeZone == "" {
+		request.TimeZone = "Asia/Kuala_Lumpur"
+	}
+
+	durations := []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{
+		{"interval", o.Interval, &request.Interval},
+		{"jitter", o.Jitter, &request.Jitter},
+		{"catch-up window", o.CatchupWindow, &request.CatchupWindow},
+	}
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(duration.value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid %s %q", ErrInvalidArgument, duration.name, duration.value)
+		}
+		*duration.field = parsed
+	}
+
+	var err error
+	if request.DaysOfMonth, err = ParseDaysOfMonth(o.DaysOfMonth); err != nil {
+		return err
+	}
+	if request.Weekdays, err = ParseWeekdays(o.Weekdays); err != nil {
+		return err
+	}
+	if request.Dunning, err = ParseDunningSchedule(o.Dunning); err != nil {
+		return err
+	}
+	if request.Overlap, err = ParseOverlapPolicy(o.Overlap); err != nil {
+		return err
+	}
+	if request.Start, err = ParseScheduleDate(o.Start, request.TimeZone); err != nil {
+		return fmt.Errorf("%w: invalid start: %v", ErrInvalidArgument, err)
+	}
+	if request.End, err = ParseScheduleEnd(o.End, request.TimeZone); err != nil {
+		return fmt.Errorf("%w: invalid end: %v", ErrInvalidArgument, err)
+	}
+	return nil
+}
+
+// ParseDaysOfMonth parses a comma separated list of days of month; "last" is the last day of the month
+func ParseDaysOfMonth(value string) ([]int, error) {
+	var days []int
+	for _, field := range splitList(value) {
+		if field == "last" {
+			days = append(days, 31)
+			continue
+		}
+		day, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid day of month %q", ErrInvalidArgument, field)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// ParseScheduleDate parses an RFC3339 time, or a YYYY-MM-DD date at midnight in the time zone
+func ParseScheduleDate(value string, timeZone string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(time.DateOnly, value, location)
+}
+
+// ParseScheduleEnd parses the end of a schedule: an RFC3339 time, or a YYYY-MM-DD date, which still takes the
+// payments due that day in the time zone
+func ParseScheduleEnd(value string, timeZone string) (time.Time, error) {
+	end, err := ParseScheduleDate(value, timeZone)
+	if err != nil || end.IsZero() {
+		return end, err
+	}
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return end, nil
+	}
+	return end.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// ParseWeekdays parses a comma separated list of weekday names, e.g. "mon,thu"
+func ParseWeekdays(value string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	for _, field := range splitList(value) {
+		found := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.HasPrefix(strings.ToLower(weekday.String()), strings.ToLower(field)) && len(field) >= 3 {
+				weekdays = append(weekdays, weekday)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: invalid weekday %q", ErrInvalidArgument, field)
+		}
+	}
+	return weekdays, nil
+}
+
+// ParseDunningSchedule parses a comma separated list of retry delays after a failed charge,
+// as Go durations or whole days, e.g. "1d,3d,7d". Each delay counts from the failure, so they must be
+// positive and strictly increasing
+func ParseDunningSchedule(value string) ([]time.Duration, error) {
+	var delays []time.Duration
+	for _, field := range splitList(value) {
+		if days, ok := strings.CutSuffix(field, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid dunning delay %q", ErrInvalidArgument, field)
+			}
+			delays = append(delays, time.Duration(n)*24*time.Hour)
+			continue
+		}
+		delay, err := time.ParseDuration(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid dunning delay %q", ErrInvalidArgument, field)
+		}
+		delays = append(delays, delay)
+	}
+	if err := validateDunning(delays); err != nil {
+		return nil, err
+	}
+	return delays, nil
+}
+
+// validateDunning checks that dunning retries are after the failure and in order
+func validateDunning(delays []time.Duration) error {
+	for i, delay := range delays {
+		if delay <= 0 {
+			return fmt.Errorf("%w: dunning retries must be after the failure", ErrInvalidArgument)
+		}
+		if i > 0 && delay <= delays[i-1] {
+			return fmt.Errorf("%w: dunning retries must be in increasing order, %s is not after %s", ErrInvalidArgument, delay, delays[i-1])
+		}
+	}
+	return nil
+}
+
+// ParseOverlapPolicy parses a schedule overlap policy name, e.g. skip, buffer-one or BUFFER_ALL
+func ParseOverlapPolicy(value string) (enumspb.ScheduleOverlapPolicy, error) {
+	if value == "" {
+		return enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED, nil
+	}
+	name := "SCHEDULE_OVERLAP_POLICY_" + strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+	policy, ok := enumspb.ScheduleOverlapPolicy_value[name]
+	if !ok || policy == int32(enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED) {
+		return 0, fmt.Errorf("%w: unknown overlap policy %q, expected skip, buffer-one, buffer-all, cancel-other, terminate-other or allow-all",
+			ErrInvalidArgument, value)
+	}
+	return enumspb.ScheduleOverlapPolicy(policy), nil
+}
+
+func splitList(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
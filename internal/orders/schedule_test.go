@@ -0,0 +1,214 @@
+package orders
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/client"
+)
+
+func TestPreviewRecurringPayment(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CreateRecurringPaymentRequest
+		from    string
+		end     string
+		want    []string
+	}{
+		{
+			name:    "day 31 falls on the last day of shorter months",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{31}},
+			from:    "2025-01-15T00:00:00Z",
+			want:    []string{"2025-01-31T00:00:00Z", "2025-02-28T00:00:00Z", "2025-03-31T00:00:00Z", "2025-04-30T00:00:00Z", "2025-05-31T00:00:00Z"},
+		},
+		{
+			name:    "day 31 falls on 29 February in leap years",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{31}},
+			from:    "2028-01-15T00:00:00Z",
+			want:    []string{"2028-01-31T00:00:00Z", "2028-02-29T00:00:00Z", "2028-03-31T00:00:00Z", "2028-04-30T00:00:00Z", "2028-05-31T00:00:00Z"},
+		},
+		{
+			name:    "day 30 falls on 28 February in non-leap years",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{30}},
+			from:    "2025-01-15T00:00:00Z",
+			want:    []string{"2025-01-30T00:00:00Z", "2025-02-28T00:00:00Z", "2025-03-30T00:00:00Z", "2025-04-30T00:00:00Z", "2025-05-30T00:00:00Z"},
+		},
+		{
+			name:    "days 28 and 31 take one payment on 28 February in non-leap years",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{28, 31}},
+			from:    "2025-02-01T00:00:00Z",
+			want:    []string{"2025-02-28T00:00:00Z", "2025-03-28T00:00:00Z", "2025-03-31T00:00:00Z", "2025-04-28T00:00:00Z", "2025-04-30T00:00:00Z"},
+		},
+		{
+			name:    "days 28 and 31 take two payments in February of leap years",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{28, 31}},
+			from:    "2028-02-01T00:00:00Z",
+			want:    []string{"2028-02-28T00:00:00Z", "2028-02-29T00:00:00Z", "2028-03-28T00:00:00Z", "2028-03-31T00:00:00Z", "2028-04-28T00:00:00Z"},
+		},
+		{
+			name:    "weekdays at a time of day",
+			request: CreateRecurringPaymentRequest{Weekdays: []time.Weekday{time.Monday, time.Thursday}, TimeOfDay: "09:30"},
+			from:    "2025-01-01T00:00:00Z",
+			want:    []string{"2025-01-02T09:30:00Z", "2025-01-06T09:30:00Z", "2025-01-09T09:30:00Z", "2025-01-13T09:30:00Z", "2025-01-16T09:30:00Z"},
+		},
+		{
+			name:    "yearly dates in a time zone",
+			request: CreateRecurringPaymentRequest{YearlyDates: []string{"01-31", "07-01"}, TimeOfDay: "08:00", TimeZone: "Asia/Kuala_Lumpur"},
+			from:    "2025-03-01T00:00:00Z",
+			want:    []string{"2025-07-01T00:00:00Z", "2026-01-31T00:00:00Z", "2026-07-01T00:00:00Z", "2027-01-31T00:00:00Z", "2027-07-01T00:00:00Z"},
+		},
+		{
+			name:    "cron expression",
+			request: CreateRecurringPaymentRequest{Cron: "0 9 1 * *"},
+			from:    "2025-01-15T00:00:00Z",
+			want:    []string{"2025-02-01T09:00:00Z", "2025-03-01T09:00:00Z", "2025-04-01T09:00:00Z", "2025-05-01T09:00:00Z", "2025-06-01T09:00:00Z"},
+		},
+		{
+			name:    "skip dates are not charged",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{1}, SkipDates: []string{"2025-03-01", "2025-05-01"}},
+			from:    "2025-01-15T00:00:00Z",
+			want:    []string{"2025-02-01T00:00:00Z", "2025-04-01T00:00:00Z", "2025-06-01T00:00:00Z", "2025-07-01T00:00:00Z", "2025-08-01T00:00:00Z"},
+		},
+		{
+			name:    "a date-only end takes the payment due that day",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{15}, TimeOfDay: "12:00"},
+			from:    "2025-01-20T00:00:00Z",
+			end:     "2025-03-15",
+			want:    []string{"2025-02-15T12:00:00Z", "2025-03-15T12:00:00Z"},
+		},
+		{
+			name:    "an RFC3339 end is exact",
+			request: CreateRecurringPaymentRequest{DaysOfMonth: []int{15}, TimeOfDay: "12:00"},
+			from:    "2025-01-20T00:00:00Z",
+			end:     "2025-03-15T11:59:59Z",
+			want:    []string{"2025-02-15T12:00:00Z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.request.TimeZone == "" {
+				test.request.TimeZone = "UTC"
+			}
+			var err error
+			if test.request.End, err = ParseScheduleEnd(test.end, test.request.TimeZone); err != nil {
+				t.Fatalf("ParseScheduleEnd(%q) failed: %v", test.end, err)
+			}
+
+			// five payments, or fewer when the schedule ends first
+			times, err := PreviewRecurringPayment(test.request, mustParseTime(t, test.from), 5)
+			if err != nil {
+				t.Fatalf("PreviewRecurringPayment failed: %v", err)
+			}
+			assertTimes(t, times, test.want)
+		})
+	}
+}
+
+func TestPreviewScheduleCalendarFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar client.ScheduleCalendarSpec
+		from     string
+		want     []string
+	}{
+		{
+			name: "weekday and day of month must both match",
+			calendar: client.ScheduleCalendarSpec{
+				Hour:       []client.ScheduleRange{{Start: 9}},
+				DayOfMonth: []client.ScheduleRange{{Start: 13}},
+				DayOfWeek:  []client.ScheduleRange{{Start: int(time.Friday)}},
+			},
+			from: "2025-01-01T00:00:00Z",
+			want: []string{"2025-06-13T09:00:00Z", "2026-02-13T09:00:00Z", "2026-03-13T09:00:00Z"},
+		},
+		{
+			name: "weekday range with a step on the last days of the month",
+			calendar: client.ScheduleCalendarSpec{
+				DayOfMonth: []client.ScheduleRange{{Start: 25, End: 31}},
+				DayOfWeek:  []client.ScheduleRange{{Start: int(time.Monday), End: int(time.Friday), Step: 2}},
+			},
+			from: "2025-01-24T00:00:00Z",
+			want: []string{"2025-01-27T00:00:00Z", "2025-01-29T00:00:00Z", "2025-01-31T00:00:00Z", "2025-02-26T00:00:00Z", "2025-02-28T00:00:00Z"},
+		},
+		{
+			name: "every hour in a range of hours",
+			calendar: client.ScheduleCalendarSpec{
+				Hour:       []client.ScheduleRange{{Start: 22, End: 23}},
+				DayOfMonth: []client.ScheduleRange{{Start: 31}},
+			},
+			from: "2025-01-01T00:00:00Z",
+			want: []string{"2025-01-31T22:00:00Z", "2025-01-31T23:00:00Z", "2025-03-31T22:00:00Z", "2025-03-31T23:00:00Z", "2025-05-31T22:00:00Z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := client.ScheduleSpec{TimeZoneName: "UTC", Calendars: []client.ScheduleCalendarSpec{test.calendar}}
+			times, err := previewSchedule(spec, mustParseTime(t, test.from), len(test.want))
+			if err != nil {
+				t.Fatalf("previewSchedule failed: %v", err)
+			}
+			assertTimes(t, times, test.want)
+		})
+	}
+}
+
+func TestParseScheduleEnd(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: "0001-01-01T00:00:00Z"},
+		{value: "2025-03-15", want: "2025-03-15T23:59:59.999999999+08:00"},
+		{value: "2025-03-15T10:00:00Z", want: "2025-03-15T10:00:00Z"},
+	}
+
+	for _, test := range tests {
+		end, err := ParseScheduleEnd(test.value, "Asia/Kuala_Lumpur")
+		if err != nil {
+			t.Fatalf("ParseScheduleEnd(%q) failed: %v", test.value, err)
+		}
+		if got := end.Format(time.RFC3339Nano); got != test.want {
+			t.Errorf("ParseScheduleEnd(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestParseDunningSchedule(t *testing.T) {
+	delays, err := ParseDunningSchedule("12h,1d,3d")
+	if err != nil {
+		t.Fatalf("ParseDunningSchedule failed: %v", err)
+	}
+	if want := []time.Duration{12 * time.Hour, 24 * time.Hour, 72 * time.Hour}; !slices.Equal(delays, want) {
+		t.Errorf("ParseDunningSchedule = %v, want %v", delays, want)
+	}
+
+	for _, value := range []string{"0d", "-1h", "1d,3d,3d", "3d,1d"} {
+		if _, err := ParseDunningSchedule(value); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("ParseDunningSchedule(%q) error = %v, want ErrInvalidArgument", value, err)
+		}
+	}
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("invalid time %q: %v", value, err)
+	}
+	return parsed
+}
+
+func assertTimes(t *testing.T, times []time.Time, want []string) {
+	t.Helper()
+	var got []string
+	for _, at := range times {
+		got = append(got, at.UTC().Format(time.RFC3339))
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
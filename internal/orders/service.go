@@ -0,0 +1,237 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+)
+
+var (
+	idPattern  = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+	Priorities = []string{"low", "normal", "high", "urgent"}
+)
+
+// Config holds the task queues the service starts workflows on
+type Config struct {
+	QueryOrderQueue        string
+	RecurringScheduleQueue string
+}
+
+// Service submits, inspects and resolves orders on top of the WorkflowManager, shared by the HTTP and gRPC servers
+type Service struct {
+	workflowManager *temporal.WorkflowManager
+	config          Config
+}
+
+func NewService(workflowManager *temporal.WorkflowManager, config Config) *Service {
+	return &Service{
+		workflowManager: workflowManager,
+		config:          config,
+	}
+}
+
+// SubmitOrderRequest represents a payment service submitting an order to be queried
+type SubmitOrderRequest struct {
+	OrderID      string
+	Environment  string
+	BusinessUnit string
+	Priority     string
+	Lifecycle    bool // use the single OrderLifecycle workflow instead of QueryOrder
+}
+
+// SubmitOrderResult represents the workflow handling a submitted order
+type SubmitOrderResult struct {
+	OrderID    string `json:"orderID"`
+	WorkflowID string `json:"workflowID"`
+	RunID      string `json:"runID,omitempty"`
+	Duplicate  bool   `json:"duplicate"`
+}
+
+// OrderWorkflow represents one workflow in the escalation path of an order
+type OrderWorkflow struct {
+	WorkflowID   string     `json:"workflowID"`
+	RunID        string     `json:"runID"`
+	WorkflowType string     `json:"workflowType"`
+	Status       string     `json:"status"`
+	Stage        string     `json:"stage,omitempty"`
+	StartTime    time.Time  `json:"startTime"`
+	CloseTime    *time.Time `json:"closeTime,omitempty"`
+}
+
+// OrderStatus represents the state of an order across its query, stale and manual workflows
+type OrderStatus struct {
+	OrderID   string          `json:"orderID"`
+	Workflows []OrderWorkflow `json:"workflows"`
+}
+
+// ResolveOrderRequest represents a manual resolution of an order
+type ResolveOrderRequest struct {
+	OrderID    string
+	Resolution string
+	Operator   string // optional, recorded in the audit trail of manual workflows
+	Comment    string
+}
+
+// ResolveOrderResult represents the workflow a resolution was sent to
+type ResolveOrderResult struct {
+	OrderID    string `json:"orderID"`
+	WorkflowID string `json:"workflowID"`
+	Resolution string `json:"resolution"`
+}
+
+// WorkflowIDs returns the workflow IDs an order moves through, in escalation order
+func WorkflowIDs(orderID string) []string {
+	workflowID := fmt.Sprintf("payment-%s", orderID)
+	return []string{workflowID, "stale-" + workflowID, "manual-stale-" + workflowID}
+}
+
+// ValidateID checks an order or consent ID before it is used as a workflow or schedule ID
+func ValidateID(kind string, id string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: %s ID must be 1-128 letters, digits or ._:-", ErrInvalidArgument, kind)
+	}
+	return nil
+}
+
+func validatePriority(priority string) error {
+	if !slices.Contains(Priorities, priority) {
+		return fmt.Errorf("%w: priority must be one of %v", ErrInvalidArgument, Priorities)
+	}
+	return nil
+}
+
+// SubmitOrder starts the workflow for an order; submitting the same order again is reported as a duplicate
+func (s *Service) SubmitOrder(ctx context.Context, request SubmitOrderRequest) (SubmitOrderResult, error) {
+	if err := ValidateID("order", request.OrderID); err != nil {
+		return SubmitOrderResult{}, err
+	}
+	if err := validatePriority(request.Priority); err != nil {
+		return SubmitOrderResult{}, err
+	}
+
+	// Use orderID as the workflow ID to prevent duplicates
+	options := temporal.StartWorkflowOptions{
+		WorkflowID:   WorkflowIDs(request.OrderID)[0],
+		TaskQueue:    s.config.QueryOrderQueue,
+		OrderID:      request.OrderID,
+		Environment:  request.Environment,
+		BusinessUnit: request.BusinessUnit,
+		Priority:     request.Priority,
+	}
+
+	var workflowFunc any = workflows.QueryOrder
+	var arg any = request.OrderID
+	if request.Lifecycle {
+		workflowFunc, arg = workflows.OrderLifecycle, models.OrderLifecycleRequest{OrderID: request.OrderID}
+	}
+
+	result := SubmitOrderResult{OrderID: request.OrderID, WorkflowID: options.WorkflowID}
+	run, err := s.workflowManager.StartWorkflow(ctx, options, workflowFunc, arg)
+	if err != nil {
+		if temporal.IsAlreadyStarted(err) {
+			result.Duplicate = true
+			return result, nil
+		}
+		return result, err
+	}
+
+	log.Printf("Started workflow %s for order %s", run.GetID(), request.OrderID)
+	result.RunID = run.GetRunID()
+	return result, nil
+}
+
+// GetOrderStatus describes the workflows an order has moved through so far
+func (s *Service) GetOrderStatus(ctx context.Context, orderID string) (OrderStatus, error) {
+	if err := ValidateID("order", orderID); err != nil {
+		return OrderStatus{}, err
+	}
+
+	status := OrderStatus{OrderID: orderID, Workflows: []OrderWorkflow{}}
+	for _, workflowID := range WorkflowIDs(orderID) {
+		description, err := s.workflowManager.DescribeWorkflow(ctx, workflowID, "")
+		if temporal.IsNotFound(err) {
+			break
+		}
+		if err != nil {
+			return status, err
+		}
+
+		status.Workflows = append(status.Workflows, orderWorkflow(description.GetWorkflowExecutionInfo()))
+	}
+
+	if len(status.Workflows) == 0 {
+		return status, fmt.Errorf("%w: order %s", ErrNotFound, orderID)
+	}
+	return status, nil
+}
+
+// ResolveOrder resolves the workflow currently waiting for the order: the manual workflow,
+// or the OrderLifecycle workflow in its manual stage
+func (s *Service) ResolveOrder(ctx context.Context, request ResolveOrderRequest) (ResolveOrderResult, error) {
+	if err := ValidateID("order", request.OrderID); err != nil {
+		return ResolveOrderResult{}, err
+	}
+	if request.Resolution == "" {
+		return ResolveOrderResult{}, fmt.Errorf("%w: resolution is required", ErrInvalidArgument)
+	}
+
+	workflowIDs := WorkflowIDs(request.OrderID)
+	for _, workflowID := range []string{workflowIDs[2], workflowIDs[0]} {
+		description, err := s.workflowManager.DescribeWorkflow(ctx, workflowID, "")
+		if temporal.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return ResolveOrderResult{}, err
+		}
+
+		info := description.GetWorkflowExecutionInfo()
+		if info.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			continue
+		}
+
+		switch info.GetType().GetName() {
+		case "ManualHandleOrder":
+			if request.Operator != "" {
+				err = s.workflowManager.SignalWorkflow(ctx, workflowID, "", workflows.SignalManualCaseAction, models.ManualCaseAction{
+					Action:     models.CaseActionResolve,
+					Operator:   request.Operator,
+					Resolution: request.Resolution,
+					Comment:    request.Comment,
+				})
+			} else {
+				err = s.workflowManager.SignalWorkflow(ctx, workflowID, "", workflows.SignalResolveManualOrder, request.Resolution)
+			}
+		case "OrderLifecycle":
+			if temporal.SearchAttributeString(info.GetSearchAttributes(), "CustomKeywordField") != models.OrderStageManual {
+				return ResolveOrderResult{}, fmt.Errorf("%w: order %s is not waiting for manual resolution", ErrConflict, request.OrderID)
+			}
+			err = s.workflowManager.SignalWorkflow(ctx, workflowID, "", workflows.SignalResolveManualOrder, request.Resolution)
+		default:
+			continue
+		}
+		if err != nil {
+			return ResolveOrderResult{}, err
+		}
+
+		log.Printf("Sent resolution '%s' to workflow %s", request.Resolution, workflowID)
+		return ResolveOrderResult{OrderID: request.OrderID, WorkflowID: workflowID, Resolution: request.Resolution}, nil
+	}
+
+	return ResolveOrderResult{}, fmt.Errorf("%w: order %s is not waiting for manual resolution", ErrConflict, request.OrderID)
+}
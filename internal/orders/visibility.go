@@ -0,0 +1,303 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// stageAttribute is the search attribute holding the stage of an order workflow. Workflows started by a client
+// hold their environment in it until they escalate to the stale or manual stage
+const stageAttribute = "CustomKeywordField"
+
+// orderAttribute is the search attribute holding the order ID of an order workflow
+const orderAttribute = "CustomStringField"
+
+// queryStageWorkflows are the workflow types that query an order before it escalates
+var queryStageWorkflows = []string{"QueryOrder", "OrderLifecycle"}
+
+// CountGroups maps the --group-by names the server can group by to their visibility fields; it only groups
+// by ExecutionStatus, so stages and workflow types are counted one value at a time
+var CountGroups = map[string]string{
+	"status": "ExecutionStatus",
+}
+
+// countedWorkflowTypes are the workflow types the worker runs, counted one by one when grouping by type
+var countedWorkflowTypes = []string{
+	"QueryOrder", "OrderLifecycle", "Stale", "ManualHandleOrder", "CircuitBreaker", "ProcessingControl",
+	recurringPaymentWorkflowType, "RecurringPaymentDunning",
+}
+
+// WorkflowFilter selects workflows through visibility; empty fields match every workflow
+type WorkflowFilter struct {
+	OrderID       string
+	Stage         string // query, stale or manual
+	Environment   string // only indexed until an order escalates, so it cannot be combined with the stale or manual stage
+	BusinessUnit  string // kept in the memo of the workflows a client starts, so it is matched on the listed pages and cannot be counted
+	Status        string // e.g. Running, Completed, Failed or TimedOut, case insensitive
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+// WorkflowPage is one page of a workflow listing
+type WorkflowPage struct {
+	Workflows     []OrderWorkflow `json:"workflows"`
+	NextPageToken []byte          `json:"nextPageToken,omitempty"` // more workflows match when set
+}
+
+// WorkflowCount counts the workflows matching a filter, in total and per group
+type WorkflowCount struct {
+	Count  int64        `json:"count"`
+	Groups []CountGroup `json:"groups,omitempty"`
+}
+
+// CountGroup counts the workflows with one value of the grouped field
+type CountGroup struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Query builds the visibility query of the filter, without the business unit, which is not indexed
+func (f WorkflowFilter) Query() (string, error) {
+	var clauses []string
+
+	if f.OrderID != "" {
+		if err := ValidateID("order", f.OrderID); err != nil {
+			return "", err
+		}
+		clauses = append(clauses, fmt.Sprintf("%s = '%s'", orderAttribute, f.OrderID))
+	}
+
+	switch f.Stage {
+	case "":
+	case models.OrderStageQuery:
+		clauses = append(clauses, fmt.Sprintf("WorkflowType IN ('%s')", strings.Join(queryStageWorkflows, "', '")),
+			fmt.Sprintf("%s NOT IN ('%s', '%s')", stageAttribute, models.OrderStageStale, models.OrderStageManual))
+	case models.OrderStageStale, models.OrderStageManual:
+		if f.Environment != "" {
+			return "", fmt.Errorf("%w: the environment of a workflow is only indexed in the query stage", ErrInvalidArgument)
+		}
+		clauses = append(clauses, fmt.Sprintf("%s = '%s'", stageAttribute, f.Stage))
+	default:
+		return "", fmt.Errorf("%w: stage must be one of %s, %s or %s", ErrInvalidArgument,
+			models.OrderStageQuery, models.OrderStageStale, models.OrderStageManual)
+	}
+
+	if f.Environment != "" {
+		if err := validateQueryValue("environment", f.Environment); err != nil {
+			return "", err
+		}
+		clauses = append(clauses, fmt.Sprintf("%s = '%s'", stageAttribute, f.Environment))
+	}
+
+	if f.Status != "" {
+		status, err := parseExecutionStatus(f.Status)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, fmt.Sprintf("ExecutionStatus = '%s'", status))
+	}
+
+	if !f.StartedAfter.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("StartTime >= '%s'", f.StartedAfter.UTC().Format(time.RFC3339)))
+	}
+	if !f.StartedBefore.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("StartTime < '%s'", f.StartedBefore.UTC().Format(time.RFC3339)))
+	}
+
+	return strings.Join(clauses, " AND "), nil
+}
+
+// ListWorkflows returns up to pageSize workflows matching the filter from the page the token points at. With a
+// business unit the page may hold fewer workflows while the token is set
+func (s *Service) ListWorkflows(ctx context.Context, filter WorkflowFilter, pageSize int, nextPageToken []byte) (WorkflowPage, error) {
+	query, err := filter.Query()
+	if err != nil {
+		return WorkflowPage{}, err
+	}
+	if filter.BusinessUnit != "" {
+		if err := validateQueryValue("business unit", filter.BusinessUnit); err != nil {
+			return WorkflowPage{}, err
+		}
+	}
+
+	response, err := s.workflowManager.ListWorkflows(ctx, query, int32(pageSize), nextPageToken)
+	if err != nil {
+		return WorkflowPage{}, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	page := WorkflowPage{Workflows: []OrderWorkflow{}, NextPageToken: response.GetNextPageToken()}
+	for _, info := range response.GetExecutions() {
+		if filter.BusinessUnit != "" && temporal.MemoString(info.GetMemo(), "businessUnit") != filter.BusinessUnit {
+			continue
+		}
+		page.Workflows = append(page.Workflows, orderWorkflow(info))
+	}
+	return page, nil
+}
+
+// CountWorkflows counts the workflows matching the filter, grouped by status, stage or type when groupBy is set.
+// Groups without workflows are left out
+func (s *Service) CountWorkflows(ctx context.Context, filter WorkflowFilter, groupBy string) (WorkflowCount, error) {
+	if filter.BusinessUnit != "" {
+		return WorkflowCount{}, fmt.Errorf("%w: the business unit is not indexed, so it cannot be counted", ErrInvalidArgument)
+	}
+	query, err := filter.Query()
+	if err != nil {
+		return WorkflowCount{}, err
+	}
+
+	switch groupBy {
+	case "":
+		total, err := s.countWorkflows(ctx, query)
+		return WorkflowCount{Count: total}, err
+	case "stage":
+		return s.countStages(ctx, filter, query)
+	case "type":
+		return s.countTypes(ctx, query)
+	}
+	field, ok := CountGroups[groupBy]
+	if !ok {
+		return WorkflowCount{}, fmt.Errorf("%w: group by must be one of status, stage or type", ErrInvalidArgument)
+	}
+
+	response, err := s.workflowManager.CountWorkflows(ctx, strings.TrimSpace(query+" GROUP BY "+field))
+	if err != nil {
+		return WorkflowCount{}, fmt.Errorf("failed to count workflows: %w", err)
+	}
+
+	count := WorkflowCount{Count: response.GetCount()}
+	for _, group := range response.GetGroups() {
+		var values []string
+		for _, value := range group.GetGroupValues() {
+			values = append(values, temporal.PayloadString(value))
+		}
+		count.Groups = append(count.Groups, CountGroup{Value: strings.Join(values, ","), Count: group.GetCount()})
+	}
+	return count, nil
+}
+
+// countStages counts the workflows matching the filter in each stage. The stage attribute holds the environment
+// before an order escalates, so with an environment only the query stage is counted
+func (s *Service) countStages(ctx context.Context, filter WorkflowFilter, query string) (WorkflowCount, error) {
+	total, err := s.countWorkflows(ctx, query)
+	if err != nil {
+		return WorkflowCount{}, err
+	}
+
+	count := WorkflowCount{Count: total}
+	for _, stage := range []string{models.OrderStageQuery, models.OrderStageStale, models.OrderStageManual} {
+		if (filter.Stage != "" && filter.Stage != stage) || (filter.Environment != "" && stage != models.OrderStageQuery) {
+			continue
+		}
+		stageFilter := filter
+		stageFilter.Stage = stage
+		stageQuery, err := stageFilter.Query()
+		if err != nil {
+			return WorkflowCount{}, err
+		}
+		if err := s.addGroup(ctx, &count, stage, stageQuery); err != nil {
+			return WorkflowCount{}, err
+		}
+	}
+	return count, nil
+}
+
+// countTypes counts the workflows matching the query of each workflow type the worker runs
+func (s *Service) countTypes(ctx context.Context, query string) (WorkflowCount, error) {
+	total, err := s.countWorkflows(ctx, query)
+	if err != nil {
+		return WorkflowCount{}, err
+	}
+
+	count := WorkflowCount{Count: total}
+	for _, workflowType := range countedWorkflowTypes {
+		typeQuery := fmt.Sprintf("WorkflowType = '%s'", workflowType)
+		if query != "" {
+			typeQuery = query + " AND " + typeQuery
+		}
+		if err := s.addGroup(ctx, &count, workflowType, typeQuery); err != nil {
+			return WorkflowCount{}, err
+		}
+	}
+	return count, nil
+}
+
+// addGroup counts the workflows matching the query as the group of value, unless there are none
+func (s *Service) addGroup(ctx context.Context, count *WorkflowCount, value string, query string) error {
+	n, err := s.countWorkflows(ctx, query)
+	if err != nil || n == 0 {
+		return err
+	}
+	count.Groups = append(count.Groups, CountGroup{Value: value, Count: n})
+	return nil
+}
+
+func (s *Service) countWorkflows(ctx context.Context, query string) (int64, error) {
+	response, err := s.workflowManager.CountWorkflows(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count workflows: %w", err)
+	}
+	return response.GetCount(), nil
+}
+
+func orderWorkflow(info *workflowpb.WorkflowExecutionInfo) OrderWorkflow {
+	workflow := OrderWorkflow{
+		WorkflowID:   info.GetExecution().GetWorkflowId(),
+		RunID:        info.GetExecution().GetRunId(),
+		WorkflowType: info.GetType().GetName(),
+		Status:       info.GetStatus().String(),
+		Stage:        orderStage(info),
+		StartTime:    info.GetStartTime().AsTime(),
+	}
+	if info.GetCloseTime() != nil {
+		closeTime := info.GetCloseTime().AsTime()
+		workflow.CloseTime = &closeTime
+	}
+	return workflow
+}
+
+// orderStage returns the stage of an order workflow: the stale or manual stage it escalated to, query before
+// that, or empty for other workflows
+func orderStage(info *workflowpb.WorkflowExecutionInfo) string {
+	stage := temporal.SearchAttributeString(info.GetSearchAttributes(), stageAttribute)
+	switch {
+	case stage == models.OrderStageStale || stage == models.OrderStageManual:
+		return stage
+	case slices.Contains(queryStageWorkflows, info.GetType().GetName()):
+		return models.OrderStageQuery
+	default:
+		return ""
+	}
+}
+
+// parseExecutionStatus parses a workflow status name, e.g. running or TIMED_OUT, into its visibility value
+func parseExecutionStatus(value string) (string, error) {
+	name := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(value))
+	for status := range enumspb.WorkflowExecutionStatus_name {
+		if status == int32(enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED) {
+			continue
+		}
+		visibilityName := enumspb.WorkflowExecutionStatus(status).String()
+		if strings.ToLower(visibilityName) == name {
+			return visibilityName, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown workflow status %q, expected running, completed, failed, canceled, terminated, continued-as-new or timed-out",
+		ErrInvalidArgument, value)
+}
+
+// validateQueryValue rejects a value that would break out of its quotes in a visibility query
+func validateQueryValue(name string, value string) error {
+	if strings.ContainsAny(value, `'"\`) {
+		return fmt.Errorf("%w: %s must not contain quotes or backslashes", ErrInvalidArgument, name)
+	}
+	return nil
+}
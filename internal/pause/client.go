@@ -0,0 +1,57 @@
+package pause
+
+import (
+	"context"
+	"fmt"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/temporal"
+	"temporal-playground/internal/workflows"
+)
+
+// Client pauses and resumes order processing through the ProcessingControl workflow
+type Client struct {
+	workflowManager *temporal.WorkflowManager
+	taskQueue       string
+}
+
+func NewClient(workflowManager *temporal.WorkflowManager, taskQueue string) *Client {
+	return &Client{
+		workflowManager: workflowManager,
+		taskQueue:       taskQueue,
+	}
+}
+
+// PausedScopes returns the paused business units and environments; none are paused before the control workflow first starts
+func (c *Client) PausedScopes(ctx context.Context) ([]models.PausedScope, error) {
+	var paused []models.PausedScope
+	err := c.workflowManager.QueryWorkflow(ctx, workflows.ProcessingControlWorkflowID, "", workflows.QueryPausedScopes, &paused)
+	if temporal.IsNotFound(err) {
+		return nil, nil
+	}
+	return paused, err
+}
+
+// Pause holds the order workflows of a business unit or environment before their next query
+func (c *Client) Pause(ctx context.Context, request models.PauseRequest) error {
+	request.Resume = false
+	return c.send(ctx, request)
+}
+
+// Resume releases the order workflows held by a paused business unit or environment
+func (c *Client) Resume(ctx context.Context, request models.PauseRequest) error {
+	request.Resume = true
+	return c.send(ctx, request)
+}
+
+func (c *Client) send(ctx context.Context, request models.PauseRequest) error {
+	if request.Scope != models.PauseScopeBusinessUnit && request.Scope != models.PauseScopeEnvironment {
+		return fmt.Errorf("unknown pause scope %q", request.Scope)
+	}
+	if request.Value == "" {
+		return fmt.Errorf("a %s to pause or resume is required", request.Scope)
+	}
+
+	_, err := c.workflowManager.SignalWithStartWorkflow(ctx, workflows.ProcessingControlWorkflowID, c.taskQueue,
+		workflows.SignalProcessingControl, request, workflows.ProcessingControl, models.ProcessingControlState{})
+	return err
+}
@@ -0,0 +1,163 @@
+package provision
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"temporal-playground/internal/models"
+	"temporal-playground/internal/orders"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment is the desired state of the namespaces of an environment
+type Environment struct {
+	Namespaces []Namespace `yaml:"namespaces"`
+}
+
+// Namespace is the desired state of a namespace; description, owner and data are only changed when set
+type Namespace struct {
+	Name             string            `yaml:"name"`
+	Description      string            `yaml:"description"`
+	OwnerEmail       string            `yaml:"ownerEmail"`
+	RetentionDays    int               `yaml:"retentionDays"` // defaults to 7
+	Data             map[string]string `yaml:"data"`
+	SearchAttributes map[string]string `yaml:"searchAttributes"` // name to type, e.g. Keyword, Text, Int, Double, Bool, Datetime or KeywordList
+	Schedules        []Schedule        `yaml:"schedules"`
+}
+
+// Schedule is a recurring payment schedule of the namespace, with the flags of create-recurring-payment
+type Schedule struct {
+	ConsentID      string            `yaml:"consentID"`
+	CustomerID     string            `yaml:"customerID"`
+	Amount         string            `yaml:"amount"`
+	Currency       string            `yaml:"currency"` // defaults to MYR
+	Metadata       map[string]string `yaml:"metadata"`
+	Terms          int               `yaml:"terms"`
+	Interval       time.Duration     `yaml:"interval"`
+	Cron           string            `yaml:"cron"`
+	DaysOfMonth    string            `yaml:"dayOfMonth"`
+	Weekdays       string            `yaml:"weekday"`
+	YearlyDates    []string          `yaml:"yearly"`
+	TimeOfDay      string            `yaml:"at"`
+	TimeZone       string            `yaml:"timezone"` // defaults to Asia/Kuala_Lumpur
+	Start          string            `yaml:"start"`
+	End            string            `yaml:"end"`
+	Jitter         time.Duration     `yaml:"jitter"`
+	SkipDates      []string          `yaml:"skip"`
+	Dunning        string            `yaml:"dunning"`
+	Overlap        string            `yaml:"overlap"`
+	CatchupWindow  time.Duration     `yaml:"catchupWindow"`
+	PauseOnFailure bool              `yaml:"pauseOnFailure"`
+	Environment    string            `yaml:"environment"`
+	BusinessUnit   string            `yaml:"businessUnit"`
+	Priority       string            `yaml:"priority"`
+}
+
+// LoadEnvironment reads the desired state of an environment from a YAML file
+func LoadEnvironment(path string) (Environment, error) {
+	var environment Environment
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return environment, fmt.Errorf("failed to read environment '%s': %w", path, err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&environment); err != nil {
+		return environment, fmt.Errorf("failed to parse environment '%s': %w", path, err)
+	}
+
+	names := map[string]bool{}
+	for i, namespace := range environment.Namespaces {
+		if namespace.Name == "" {
+			return environment, fmt.Errorf("namespace %d of environment '%s' has no name", i+1, path)
+		}
+		if names[namespace.Name] {
+			return environment, fmt.Errorf("namespace '%s' is declared twice in environment '%s'", namespace.Name, path)
+		}
+		names[namespace.Name] = true
+
+		if namespace.RetentionDays == 0 {
+			environment.Namespaces[i].RetentionDays = 7
+		}
+		for name, valueType := range namespace.SearchAttributes {
+			if _, err := IndexedValueType(valueType); err != nil {
+				return environment, fmt.Errorf("search attribute '%s' of namespace '%s': %w", name, namespace.Name, err)
+			}
+		}
+	}
+	return environment, nil
+}
+
+// IndexedValueType parses a search attribute type, e.g. Keyword or KEYWORD_LIST
+func IndexedValueType(value string) (enumspb.IndexedValueType, error) {
+	name := strings.ToUpper(strings.ReplaceAll(value, "List", "_List"))
+	valueType, ok := enumspb.IndexedValueType_value["INDEXED_VALUE_TYPE_"+name]
+	if !ok || valueType == int32(enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown search attribute type %q, expected Keyword, Text, Int, Double, Bool, Datetime or KeywordList", value)
+	}
+	return enumspb.IndexedValueType(valueType), nil
+}
+
+// request translates the schedule into the request create-recurring-payment sends
+func (s Schedule) request() (orders.CreateRecurringPaymentRequest, error) {
+	request := orders.CreateRecurringPaymentRequest{
+		ConsentID:      s.ConsentID,
+		CustomerID:     s.CustomerID,
+		Metadata:       s.Metadata,
+		Terms:          s.Terms,
+		Interval:       s.Interval,
+		Cron:           s.Cron,
+		YearlyDates:    s.YearlyDates,
+		TimeOfDay:      s.TimeOfDay,
+		TimeZone:       s.TimeZone,
+		Jitter:         s.Jitter,
+		SkipDates:      s.SkipDates,
+		CatchupWindow:  s.CatchupWindow,
+		PauseOnFailure: s.PauseOnFailure,
+		Environment:    s.Environment,
+		BusinessUnit:   s.BusinessUnit,
+		Priority:       s.Priority,
+	}
+	if request.TimeZone == "" {
+		request.TimeZone = "Asia/Kuala_Lumpur"
+	}
+	if request.Priority == "" {
+		request.Priority = "normal"
+	}
+	currency := s.Currency
+	if currency == "" {
+		currency = "MYR"
+	}
+
+	var err error
+	if request.Amount, err = models.ParseMoney(s.Amount, currency); err != nil {
+		return request, fmt.Errorf("invalid amount: %w", err)
+	}
+	if request.Dunning, err = orders.ParseDunningSchedule(s.Dunning); err != nil {
+		return request, err
+	}
+	if request.Overlap, err = orders.ParseOverlapPolicy(s.Overlap); err != nil {
+		return request, err
+	}
+	if request.DaysOfMonth, err = orders.ParseDaysOfMonth(s.DaysOfMonth); err != nil {
+		return request, err
+	}
+	if request.Weekdays, err = orders.ParseWeekdays(s.Weekdays); err != nil {
+		return request, err
+	}
+	if request.Start, err = orders.ParseScheduleDate(s.Start, request.TimeZone); err != nil {
+		return request, fmt.Errorf("invalid start: %w", err)
+	}
+	if request.End, err = orders.ParseScheduleEnd(s.End, request.TimeZone); err != nil {
+		return request, fmt.Errorf("invalid end: %w", err)
+	}
+	if _, err := request.ScheduleSpec(); err != nil {
+		return request, err
+	}
+	return request, nil
+}
@@ -0,0 +1,351 @@
+package provision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"temporal-playground/internal/orders"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// namespaceReadyTimeout bounds how long Apply waits for a namespace it registered to accept requests
+const namespaceReadyTimeout = 30 * time.Second
+
+// Action is what applying a change does, shown as its plan symbol
+type Action string
+
+const (
+	ActionCreate    Action = "+"
+	ActionUpdate    Action = "~"
+	ActionUnchanged Action = "="
+	ActionConflict  Action = "!" // the server differs in a way apply cannot change, e.g. a search attribute type
+)
+
+// Change is one difference between the environment file and the server
+type Change struct {
+	Action    Action `json:"action"`
+	Kind      string `json:"kind"` // namespace, search-attribute or schedule
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Detail    string `json:"detail,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// Plan lists the changes applying an environment makes, in the order they are applied
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Pending counts the changes apply makes
+func (p Plan) Pending() int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.apply != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Conflicts returns the changes apply refuses to make
+func (p Plan) Conflicts() []Change {
+	var conflicts []Change
+	for _, change := range p.Changes {
+		if change.Action == ActionConflict {
+			conflicts = append(conflicts, change)
+		}
+	}
+	return conflicts
+}
+
+// Provisioner diffs an environment file against the server and applies the differences. Applying is idempotent:
+// existing namespaces and schedules are updated only where they differ, and existing search attributes are kept
+type Provisioner struct {
+	options          client.Options
+	config           orders.Config
+	namespaceManager *temporal.NamespaceManager
+	workflowManagers map[string]*temporal.WorkflowManager
+}
+
+func NewProvisioner(options client.Options, config orders.Config) *Provisioner {
+	return &Provisioner{
+		options:          options,
+		config:           config,
+		namespaceManager: temporal.NewNamespaceManager(options),
+		workflowManagers: map[string]*temporal.WorkflowManager{},
+	}
+}
+
+func (p *Provisioner) Close() {
+	for _, workflowManager := range p.workflowManagers {
+		workflowManager.Close()
+	}
+	p.namespaceManager.Close()
+}
+
+// Plan compares the environment with the namespaces, search attributes and schedules on the server
+func (p *Provisioner) Plan(ctx context.Context, environment Environment) (Plan, error) {
+	var plan Plan
+	for _, namespace := range environment.Namespaces {
+		changes, err := p.planNamespace(ctx, namespace)
+		if err != nil {
+			return Plan{}, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return plan, nil
+}
+
+// Apply makes the pending changes of a plan in order; it refuses a plan with conflicts
+func (p *Provisioner) Apply(ctx context.Context, plan Plan) error {
+	if conflicts := plan.Conflicts(); len(conflicts) > 0 {
+		return fmt.Errorf("plan has %d conflicts, e.g. %s %s in namespace %s: %s",
+			len(conflicts), conflicts[0].Kind, conflicts[0].Name, conflicts[0].Namespace, conflicts[0].Detail)
+	}
+
+	for _, change := range plan.Changes {
+		if change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx); err != nil {
+			return fmt.Errorf("failed to apply %s %s %s in namespace %s: %w", change.Action, change.Kind, change.Name, change.Namespace, err)
+		}
+	}
+	return nil
+}
+
+func (p *Provisioner) planNamespace(ctx context.Context, namespace Namespace) ([]Change, error) {
+	retention := time.Duration(namespace.RetentionDays) * 24 * time.Hour
+
+	current, err := p.namespaceManager.DescribeNamespace(ctx, namespace.Name)
+	if temporal.IsNamespaceNotFound(err) {
+		changes := []Change{{
+			Action:    ActionCreate,
+			Kind:      "namespace",
+			Namespace: namespace.Name,
+			Name:      namespace.Name,
+			Detail:    fmt.Sprintf("retention %dd", namespace.RetentionDays),
+			apply: func(ctx context.Context) error {
+				if err := p.namespaceManager.RegisterNamespace(ctx, namespace.Name, namespace.Description, namespace.RetentionDays); err != nil {
+					return err
+				}
+				if namespace.OwnerEmail == "" && len(namespace.Data) == 0 {
+					return nil
+				}
+				return p.whenReady(ctx, func(ctx context.Context) error {
+					_, err := p.namespaceManager.UpdateNamespace(ctx, namespace.Name, temporal.NamespaceUpdate{
+						OwnerEmail: &namespace.OwnerEmail,
+						Data:       namespace.Data,
+					})
+					return err
+				})
+			},
+		}}
+		for _, name := range slices.Sorted(maps.Keys(namespace.SearchAttributes)) {
+			changes = append(changes, p.addSearchAttribute(namespace.Name, name, namespace.SearchAttributes[name]))
+		}
+		for _, schedule := range namespace.Schedules {
+			change, err := p.createSchedule(namespace.Name, schedule)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+		return changes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{p.updateNamespace(namespace, current, retention)}
+
+	for _, name := range slices.Sorted(maps.Keys(namespace.SearchAttributes)) {
+		valueType, _ := IndexedValueType(namespace.SearchAttributes[name])
+		wanted := valueTypeName(valueType)
+		existing, ok := current.SearchAttributes[name]
+		switch {
+		case !ok:
+			changes = append(changes, p.addSearchAttribute(namespace.Name, name, namespace.SearchAttributes[name]))
+		case existing != wanted:
+			changes = append(changes, Change{
+				Action:    ActionConflict,
+				Kind:      "search-attribute",
+				Namespace: namespace.Name,
+				Name:      name,
+				Detail:    fmt.Sprintf("is %s on the server, %s in the environment; search attribute types cannot be changed", existing, wanted),
+			})
+		default:
+			changes = append(changes, Change{Action: ActionUnchanged, Kind: "search-attribute", Namespace: namespace.Name, Name: name, Detail: existing})
+		}
+	}
+
+	service := orders.NewService(p.workflowManager(namespace.Name), p.config)
+	for _, schedule := range namespace.Schedules {
+		from := time.Now()
+		payment, err := service.GetRecurringPayment(ctx, schedule.ConsentID)
+		switch {
+		case errors.Is(err, orders.ErrNotFound):
+			change, err := p.createSchedule(namespace.Name, schedule)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get recurring payment %s of namespace '%s': %w", schedule.ConsentID, namespace.Name, err)
+		default:
+			change, err := p.updateSchedule(namespace.Name, schedule, payment, from)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// updateNamespace changes the description, owner, retention and data of an existing namespace where they differ
+func (p *Provisioner) updateNamespace(namespace Namespace, current temporal.NamespaceDescription, retention time.Duration) Change {
+	var (
+		update  temporal.NamespaceUpdate
+		details []string
+	)
+	if namespace.Description != "" && namespace.Description != current.Description {
+		update.Description = &namespace.Description
+		details = append(details, "description")
+	}
+	if namespace.OwnerEmail != "" && namespace.OwnerEmail != current.OwnerEmail {
+		update.OwnerEmail = &namespace.OwnerEmail
+		details = append(details, "owner email")
+	}
+	if retention != current.Retention {
+		update.Retention = &retention
+		details = append(details, fmt.Sprintf("retention %s -> %dd", current.Retention, namespace.RetentionDays))
+	}
+	for _, key := range slices.Sorted(maps.Keys(namespace.Data)) {
+		if value, ok := current.Data[key]; !ok || value != namespace.Data[key] {
+			if update.Data == nil {
+				update.Data = map[string]string{}
+			}
+			update.Data[key] = namespace.Data[key]
+			details = append(details, "data "+key)
+		}
+	}
+
+	change := Change{Kind: "namespace", Namespace: namespace.Name, Name: namespace.Name}
+	if len(details) == 0 {
+		change.Action = ActionUnchanged
+		return change
+	}
+	change.Action = ActionUpdate
+	change.Detail = strings.Join(details, ", ")
+	change.apply = func(ctx context.Context) error {
+		_, err := p.namespaceManager.UpdateNamespace(ctx, namespace.Name, update)
+		return err
+	}
+	return change
+}
+
+func (p *Provisioner) addSearchAttribute(namespaceName string, name string, value string) Change {
+	valueType, _ := IndexedValueType(value)
+	return Change{
+		Action:    ActionCreate,
+		Kind:      "search-attribute",
+		Namespace: namespaceName,
+		Name:      name,
+		Detail:    valueTypeName(valueType),
+		apply: func(ctx context.Context) error {
+			return p.whenReady(ctx, func(ctx context.Context) error {
+				return p.namespaceManager.AddSearchAttributes(ctx, namespaceName, map[string]enumspb.IndexedValueType{name: valueType})
+			})
+		},
+	}
+}
+
+// createSchedule validates the schedule up front, so a bad environment file fails the plan rather than the apply
+func (p *Provisioner) createSchedule(namespaceName string, schedule Schedule) (Change, error) {
+	request, err := schedule.request()
+	if err != nil {
+		return Change{}, fmt.Errorf("recurring payment %s of namespace '%s': %w", schedule.ConsentID, namespaceName, err)
+	}
+	return Change{
+		Action:    ActionCreate,
+		Kind:      "schedule",
+		Namespace: namespaceName,
+		Name:      schedule.ConsentID,
+		Detail:    request.Amount.String(),
+		apply: func(ctx context.Context) error {
+			service := orders.NewService(p.workflowManager(namespaceName), p.config)
+			return p.whenReady(ctx, func(ctx context.Context) error {
+				_, err := service.CreateRecurringPayment(ctx, request)
+				return err
+			})
+		},
+	}, nil
+}
+
+// updateSchedule replaces an existing recurring payment with the one in the environment where they differ;
+// see orders.ReplaceRecurringPayment for what is kept
+func (p *Provisioner) updateSchedule(namespaceName string, schedule Schedule, payment orders.RecurringPayment, from time.Time) (Change, error) {
+	request, err := schedule.request()
+	if err != nil {
+		return Change{}, fmt.Errorf("recurring payment %s of namespace '%s': %w", schedule.ConsentID, namespaceName, err)
+	}
+	details, err := orders.RecurringPaymentChanges(payment, request, from)
+	if err != nil {
+		return Change{}, fmt.Errorf("recurring payment %s of namespace '%s': %w", schedule.ConsentID, namespaceName, err)
+	}
+
+	change := Change{Kind: "schedule", Namespace: namespaceName, Name: schedule.ConsentID}
+	if len(details) == 0 {
+		change.Action = ActionUnchanged
+		change.Detail = request.Amount.String()
+		return change, nil
+	}
+	change.Action = ActionUpdate
+	change.Detail = strings.Join(details, ", ")
+	change.apply = func(ctx context.Context) error {
+		service := orders.NewService(p.workflowManager(namespaceName), p.config)
+		_, err := service.ReplaceRecurringPayment(ctx, request)
+		return err
+	}
+	return change, nil
+}
+
+// whenReady retries a request while the namespace is not found, as a newly registered namespace takes a few seconds
+// to reach every frontend
+func (p *Provisioner) whenReady(ctx context.Context, request func(ctx context.Context) error) error {
+	deadline := time.Now().Add(namespaceReadyTimeout)
+	for {
+		err := request(ctx)
+		if err == nil || !temporal.IsNamespaceNotFound(err) || time.Now().After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (p *Provisioner) workflowManager(namespaceName string) *temporal.WorkflowManager {
+	if p.workflowManagers[namespaceName] == nil {
+		options := p.options
+		options.Namespace = namespaceName
+		p.workflowManagers[namespaceName] = temporal.NewWorkflowManager(options)
+	}
+	return p.workflowManagers[namespaceName]
+}
+
+// valueTypeName names a search attribute type the way DescribeNamespace does, e.g. KeywordList
+func valueTypeName(valueType enumspb.IndexedValueType) string {
+	return strings.TrimPrefix(valueType.String(), "INDEXED_VALUE_TYPE_")
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"temporal-playground/internal/models"
+)
+
+const createChargesTable = `CREATE TABLE IF NOT EXISTS charges (
+	idempotency_key TEXT PRIMARY KEY,
+	consent_id      TEXT NOT NULL,
+	amount_minor    BIGINT NOT NULL,
+	currency        TEXT NOT NULL,
+	scheduled_at    TIMESTAMP NOT NULL,
+	workflow_id     TEXT NOT NULL,
+	run_id          TEXT NOT NULL,
+	attempt         INTEGER NOT NULL,
+	result          TEXT NOT NULL DEFAULT '',
+	charged_at      TIMESTAMP NOT NULL
+)`
+
+// a key that is already recorded inserts nothing, which RecordCharge reports as a duplicate
+const insertCharge = `INSERT INTO charges (idempotency_key, consent_id, amount_minor, currency, scheduled_at,
+	workflow_id, run_id, attempt, result, charged_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (idempotency_key) DO NOTHING`
+
+const selectCharges = `SELECT idempotency_key, consent_id, amount_minor, currency, scheduled_at,
+	workflow_id, run_id, attempt, result, charged_at FROM charges`
+
+func (r *sqlRepository) RecordCharge(ctx context.Context, record models.ChargeRecord) error {
+	result, err := r.db.ExecContext(ctx, r.rebind(insertCharge),
+		record.IdempotencyKey, record.ConsentID, record.Amount.Minor, record.Amount.Currency, record.ScheduledAt.UTC(),
+		record.WorkflowID, record.RunID, record.Attempt, record.Result, record.ChargedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record charge %s: %w", record.IdempotencyKey, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record charge %s: %w", record.IdempotencyKey, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("%w: %s", ErrDuplicateCharge, record.IdempotencyKey)
+	}
+	return nil
+}
+
+func (r *sqlRepository) GetCharge(ctx context.Context, idempotencyKey string) (models.ChargeRecord, error) {
+	records, err := r.queryCharges(ctx, selectCharges+" WHERE idempotency_key = ?", idempotencyKey)
+	if err != nil {
+		return models.ChargeRecord{}, err
+	}
+	if len(records) == 0 {
+		return models.ChargeRecord{}, ErrChargeNotFound
+	}
+	return records[0], nil
+}
+
+func (r *sqlRepository) ListCharges(ctx context.Context, consentID string) ([]models.ChargeRecord, error) {
+	return r.queryCharges(ctx, selectCharges+" WHERE consent_id = ? ORDER BY scheduled_at, charged_at", consentID)
+}
+
+func (r *sqlRepository) queryCharges(ctx context.Context, query string, args ...any) ([]models.ChargeRecord, error) {
+	rows, err := r.db.QueryContext(ctx, r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query charges: %w", err)
+	}
+	defer rows.Close()
+
+	var records []models.ChargeRecord
+	for rows.Next() {
+		var record models.ChargeRecord
+		if err := rows.Scan(&record.IdempotencyKey, &record.ConsentID, &record.Amount.Minor, &record.Amount.Currency, &record.ScheduledAt,
+			&record.WorkflowID, &record.RunID, &record.Attempt, &record.Result, &record.ChargedAt); err != nil {
+			return nil, fmt.Errorf("failed to read charge: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"temporal-playground/internal/models"
+	"testing"
+	"time"
+)
+
+func TestRecordChargeOncePerIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	repository, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repository.Close()
+
+	first := models.ChargeRecord{
+		IdempotencyKey: "consent-123/2025-01-01T00:00:00Z",
+		ConsentID:      "consent-123",
+		Amount:         models.Money{Minor: 4990, Currency: "MYR"},
+		ScheduledAt:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		WorkflowID:     "recurring-payment-consent-123",
+		RunID:          "run-1",
+		Attempt:        1,
+		Result:         "charged by attempt 1",
+		ChargedAt:      time.Now(),
+	}
+	if err := repository.RecordCharge(ctx, first); err != nil {
+		t.Fatalf("first RecordCharge failed: %v", err)
+	}
+
+	retry := first
+	retry.Attempt, retry.Result = 2, "charged by attempt 2"
+	if err := repository.RecordCharge(ctx, retry); !errors.Is(err, ErrDuplicateCharge) {
+		t.Fatalf("second RecordCharge returned %v, want ErrDuplicateCharge", err)
+	}
+
+	stored, err := repository.GetCharge(ctx, first.IdempotencyKey)
+	if err != nil {
+		t.Fatalf("GetCharge failed: %v", err)
+	}
+	if stored.Attempt != first.Attempt || stored.Result != first.Result {
+		t.Errorf("stored charge of attempt %d %q, want the first one", stored.Attempt, stored.Result)
+	}
+	if charges, err := repository.ListCharges(ctx, first.ConsentID); err != nil || len(charges) != 1 {
+		t.Errorf("ListCharges returned %d charges (%v), want 1", len(charges), err)
+	}
+
+	if _, err := repository.GetCharge(ctx, "consent-123/2025-02-01T00:00:00Z"); !errors.Is(err, ErrChargeNotFound) {
+		t.Errorf("GetCharge of an unrecorded term returned %v, want ErrChargeNotFound", err)
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"temporal-playground/internal/models"
+)
+
+const createDeliveriesTable = `CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	event_id      TEXT NOT NULL,
+	order_id      TEXT NOT NULL,
+	resolution    TEXT NOT NULL,
+	business_unit TEXT NOT NULL DEFAULT '',
+	endpoint      TEXT NOT NULL,
+	attempt       INTEGER NOT NULL,
+	status_code   INTEGER NOT NULL DEFAULT 0,
+	error         TEXT NOT NULL DEFAULT '',
+	delivered_at  TIMESTAMP NOT NULL,
+	PRIMARY KEY (event_id, endpoint, attempt)
+)`
+
+// an attempt recorded again, e.g. by a retried activity that failed to heartbeat, overwrites the first record
+const upsertDelivery = `INSERT INTO webhook_deliveries (event_id, order_id, resolution, business_unit, endpoint, attempt,
+	status_code, error, delivered_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (event_id, endpoint, attempt) DO UPDATE SET
+	status_code = excluded.status_code,
+	error = excluded.error,
+	delivered_at = excluded.delivered_at`
+
+const selectDeliveries = `SELECT event_id, order_id, resolution, business_unit, endpoint, attempt,
+	status_code, error, delivered_at FROM webhook_deliveries`
+
+func (r *sqlRepository) RecordDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, r.rebind(upsertDelivery),
+		delivery.EventID, delivery.OrderID, delivery.Resolution, delivery.BusinessUnit, delivery.Endpoint, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.DeliveredAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery of event %s: %w", delivery.EventID, err)
+	}
+	return nil
+}
+
+func (r *sqlRepository) ListDeliveries(ctx context.Context, orderID string) ([]models.WebhookDelivery, error) {
+	query, args := selectDeliveries, []any{}
+	if orderID != "" {
+		query += " WHERE order_id = ?"
+		args = append(args, orderID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.rebind(query+" ORDER BY delivered_at"), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(&delivery.EventID, &delivery.OrderID, &delivery.Resolution, &delivery.BusinessUnit, &delivery.Endpoint,
+			&delivery.Attempt, &delivery.StatusCode, &delivery.Error, &delivery.DeliveredAt); err != nil {
+			return nil, fmt.Errorf("failed to read webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
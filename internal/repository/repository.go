@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"temporal-playground/internal/models"
+)
+
+var (
+	ErrNotFound        = errors.New("order not found")
+	ErrChargeNotFound  = errors.New("charge not found")
+	ErrDuplicateCharge = errors.New("charge already recorded")
+)
+
+// Repository persists orders, recurring charges and webhook deliveries in one database
+type Repository interface {
+	OrderRepository
+	ChargeLedger
+	DeliveryLog
+}
+
+// OrderRepository persists the final state of orders. Saving is idempotent per order ID and workflow run,
+// so an activity retry overwrites the record it wrote before instead of adding a new one
+type OrderRepository interface {
+	SaveOrder(ctx context.Context, record models.OrderRecord) error
+	ListOrders(ctx context.Context, filter OrderFilter) ([]models.OrderRecord, error)
+	// GetOrder returns the records of every workflow run that concluded the order, oldest first
+	GetOrder(ctx context.Context, orderID string) ([]models.OrderRecord, error)
+	Close() error
+}
+
+// ChargeLedger records the recurring charges taken, at most one per idempotency key
+type ChargeLedger interface {
+	// RecordCharge stores a charge; ErrDuplicateCharge means a charge with the same key is already recorded
+	RecordCharge(ctx context.Context, record models.ChargeRecord) error
+	// GetCharge returns the charge recorded under the idempotency key, or ErrChargeNotFound
+	GetCharge(ctx context.Context, idempotencyKey string) (models.ChargeRecord, error)
+	// ListCharges returns the charges of a consent, oldest first
+	ListCharges(ctx context.Context, consentID string) ([]models.ChargeRecord, error)
+}
+
+// DeliveryLog records every webhook delivery attempt, whichever worker made it
+type DeliveryLog interface {
+	RecordDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+	// ListDeliveries returns the delivery attempts, oldest first, optionally only those of one order
+	ListDeliveries(ctx context.Context, orderID string) ([]models.WebhookDelivery, error)
+}
+
+// OrderFilter narrows down ListOrders; empty fields match everything
+type OrderFilter struct {
+	Resolution   string
+	BusinessUnit string
+	Limit        int
+}
+
+// Open returns the repository for a DSN: postgres:// and postgresql:// URLs use Postgres,
+// anything else is the path of a SQLite database file
+func Open(dsn string) (Repository, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return NewPostgresRepository(dsn)
+	}
+	return NewSQLiteRepository(dsn)
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"temporal-playground/internal/models"
+	"time"
+)
+
+const createOrdersTable = `CREATE TABLE IF NOT EXISTS orders (
+	order_id        TEXT NOT NULL,
+	workflow_id     TEXT NOT NULL,
+	run_id          TEXT NOT NULL,
+	resolution      TEXT NOT NULL,
+	resolved_by     TEXT NOT NULL DEFAULT '',
+	business_unit   TEXT NOT NULL DEFAULT '',
+	priority        TEXT NOT NULL DEFAULT '',
+	started_at      TIMESTAMP,
+	resolved_at     TIMESTAMP NOT NULL,
+	error_history   TEXT NOT NULL DEFAULT '[]',
+	escalation_path TEXT NOT NULL DEFAULT '[]',
+	recorded_at     TIMESTAMP NOT NULL,
+	PRIMARY KEY (order_id, workflow_id, run_id)
+)`
+
+const upsertOrder = `INSERT INTO orders (order_id, workflow_id, run_id, resolution, resolved_by, business_unit, priority,
+	started_at, resolved_at, error_history, escalation_path, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (order_id, workflow_id, run_id) DO UPDATE SET
+	resolution = excluded.resolution,
+	resolved_by = excluded.resolved_by,
+	business_unit = excluded.business_unit,
+	priority = excluded.priority,
+	started_at = excluded.started_at,
+	resolved_at = excluded.resolved_at,
+	error_history = excluded.error_history,
+	escalation_path = excluded.escalation_path,
+	recorded_at = excluded.recorded_at`
+
+const selectOrders = `SELECT order_id, workflow_id, run_id, resolution, resolved_by, business_unit, priority,
+	started_at, resolved_at, error_history, escalation_path, recorded_at FROM orders`
+
+// sqlRepository implements OrderRepository, ChargeLedger and DeliveryLog on database/sql; the SQL is shared by SQLite and Postgres
+// apart from the placeholder syntax
+type sqlRepository struct {
+	db                  *sql.DB
+	numberedPlaceholder bool // $1, $2, ... instead of ?
+}
+
+func newSQLRepository(db *sql.DB, numberedPlaceholder bool) (*sqlRepository, error) {
+	repository := &sqlRepository{
+		db:                  db,
+		numberedPlaceholder: numberedPlaceholder,
+	}
+	if _, err := db.Exec(createOrdersTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create orders table: %w", err)
+	}
+	if _, err := db.Exec(createChargesTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create charges table: %w", err)
+	}
+	if _, err := db.Exec(createDeliveriesTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create webhook deliveries table: %w", err)
+	}
+	return repository, nil
+}
+
+func (r *sqlRepository) SaveOrder(ctx context.Context, record models.OrderRecord) error {
+	errorHistory, err := json.Marshal(nonNil(record.ErrorHistory))
+	if err != nil {
+		return err
+	}
+	escalationPath, err := json.Marshal(nonNil(record.EscalationPath))
+	if err != nil {
+		return err
+	}
+
+	var startedAt *time.Time
+	if !record.StartedAt.IsZero() {
+		startedAt = &record.StartedAt
+	}
+
+	_, err = r.db.ExecContext(ctx, r.rebind(upsertOrder),
+		record.OrderID, record.WorkflowID, record.RunID, record.Resolution, record.ResolvedBy, record.BusinessUnit, record.Priority,
+		startedAt, record.ResolvedAt.UTC(), string(errorHistory), string(escalationPath), record.RecordedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save order %s: %w", record.OrderID, err)
+	}
+	return nil
+}
+
+func (r *sqlRepository) ListOrders(ctx context.Context, filter OrderFilter) ([]models.OrderRecord, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.Resolution != "" {
+		conditions = append(conditions, "resolution = ?")
+		args = append(args, filter.Resolution)
+	}
+	if filter.BusinessUnit != "" {
+		conditions = append(conditions, "business_unit = ?")
+		args = append(args, filter.BusinessUnit)
+	}
+
+	query := selectOrders
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY resolved_at DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(filter.Limit)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *sqlRepository) GetOrder(ctx context.Context, orderID string) ([]models.OrderRecord, error) {
+	records, err := r.query(ctx, selectOrders+" WHERE order_id = ? ORDER BY resolved_at", orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	return records, nil
+}
+
+func (r *sqlRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *sqlRepository) query(ctx context.Context, query string, args ...any) ([]models.OrderRecord, error) {
+	rows, err := r.db.QueryContext(ctx, r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	var records []models.OrderRecord
+	for rows.Next() {
+		var (
+			record                       models.OrderRecord
+			startedAt                    sql.NullTime
+			errorHistory, escalationPath string
+		)
+		if err := rows.Scan(&record.OrderID, &record.WorkflowID, &record.RunID, &record.Resolution, &record.ResolvedBy,
+			&record.BusinessUnit, &record.Priority, &startedAt, &record.ResolvedAt, &errorHistory, &escalationPath, &record.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to read order: %w", err)
+		}
+		if startedAt.Valid {
+			record.StartedAt = startedAt.Time
+		}
+		if err := json.Unmarshal([]byte(errorHistory), &record.ErrorHistory); err != nil {
+			return nil, fmt.Errorf("failed to read error history of order %s: %w", record.OrderID, err)
+		}
+		if err := json.Unmarshal([]byte(escalationPath), &record.EscalationPath); err != nil {
+			return nil, fmt.Errorf("failed to read escalation path of order %s: %w", record.OrderID, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// rebind replaces the ? placeholders with $1, $2, ... for drivers that need numbered placeholders
+func (r *sqlRepository) rebind(query string) string {
+	if !r.numberedPlaceholder {
+		return query
+	}
+
+	var (
+		builder strings.Builder
+		n       int
+	)
+	for _, char := range query {
+		if char == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
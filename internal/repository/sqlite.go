@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// NewSQLiteRepository opens (and creates if needed) a SQLite database file
+func NewSQLiteRepository(path string) (Repository, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database '%s': %w", path, err)
+	}
+	// SQLite allows a single writer; serialising on one connection avoids "database is locked" errors
+	db.SetMaxOpenConns(1)
+
+	return newSQLRepository(db, false)
+}
@@ -2,8 +2,11 @@ package temporal
 
 import (
 	"log"
+	"log/slog"
+	"os"
 
 	"go.temporal.io/sdk/client"
+	sdklog "go.temporal.io/sdk/log"
 )
 
 type ClientManager struct {
@@ -11,8 +14,7 @@ type ClientManager struct {
 }
 
 func NewClientManager(options client.Options) *ClientManager {
-
-	temporalClient, err := client.Dial(options)
+	temporalClient, err := client.Dial(withLogger(options))
 	if err != nil {
 		log.Fatalf("Failed to create Temporal client for namespace '%s': %v", options.Namespace, err)
 	}
@@ -24,7 +26,7 @@ func NewClientManager(options client.Options) *ClientManager {
 }
 
 func NewAdminClientManager(options client.Options) *ClientManager {
-	temporalClient, err := client.Dial(options)
+	temporalClient, err := client.Dial(withLogger(options))
 	if err != nil {
 		log.Fatalf("Failed to create Temporal admin client: %v", err)
 	}
@@ -47,3 +49,11 @@ func (cm *ClientManager) Close() {
 		cm.client.Close()
 	}
 }
+
+// withLogger sends the SDK logs to stderr, where the standard logger writes, instead of the SDK default of stdout
+func withLogger(options client.Options) client.Options {
+	if options.Logger == nil {
+		options.Logger = sdklog.NewStructuredLogger(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	}
+	return options
+}
@@ -0,0 +1,167 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.temporal.io/sdk/client"
+)
+
+// DeploymentManager sets which worker build serves the task queues of a worker deployment
+type DeploymentManager struct {
+	clientManager *ClientManager
+}
+
+func NewDeploymentManager(options client.Options) *DeploymentManager {
+	return &DeploymentManager{
+		clientManager: NewClientManager(options),
+	}
+}
+
+// Deployment describes the routing of a worker deployment and the builds it tracks
+type Deployment struct {
+	Name              string              `json:"name"`
+	CreatedAt         time.Time           `json:"createdAt"`
+	CurrentBuildID    string              `json:"currentBuildID,omitempty"` // empty means unversioned workers
+	RampingBuildID    string              `json:"rampingBuildID,omitempty"`
+	RampingPercentage float32             `json:"rampingPercentage,omitempty"`
+	Versions          []DeploymentVersion `json:"versions"`
+}
+
+// DeploymentVersion describes one build of a worker deployment and the task queues its workers poll
+type DeploymentVersion struct {
+	BuildID        string    `json:"buildID"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Current        bool      `json:"current"`
+	RampPercentage float32   `json:"rampPercentage,omitempty"`
+	DrainageStatus string    `json:"drainageStatus,omitempty"` // draining or drained once no longer current or ramping
+	TaskQueues     []string  `json:"taskQueues"`
+}
+
+// ListDeployments returns the worker deployments of the namespace with their routing
+func (dm *DeploymentManager) ListDeployments(ctx context.Context) ([]Deployment, error) {
+	iter, err := dm.clientManager.GetClient().WorkerDeploymentClient().List(ctx, client.WorkerDeploymentListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker deployments: %w", err)
+	}
+
+	var deployments []Deployment
+	for iter.HasNext() {
+		entry, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list worker deployments: %w", err)
+		}
+		deployment := Deployment{
+			Name:      entry.Name,
+			CreatedAt: entry.CreateTime,
+		}
+		setRouting(&deployment, entry.RoutingConfig)
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// DescribeDeployment returns the routing of a worker deployment and, for each of its builds, the task queues it polls
+func (dm *DeploymentManager) DescribeDeployment(ctx context.Context, name string) (Deployment, error) {
+	handle := dm.clientManager.GetClient().WorkerDeploymentClient().GetHandle(name)
+	description, err := handle.Describe(ctx, client.WorkerDeploymentDescribeOptions{})
+	if err != nil {
+		return Deployment{}, fmt.Errorf("failed to describe worker deployment '%s': %w", name, err)
+	}
+
+	deployment := Deployment{
+		Name:      name,
+		CreatedAt: description.Info.CreateTime,
+	}
+	setRouting(&deployment, description.Info.RoutingConfig)
+
+	for _, summary := range description.Info.VersionSummaries {
+		version := DeploymentVersion{
+			BuildID:        summary.Version.BuildId,
+			CreatedAt:      summary.CreateTime,
+			Current:        summary.Version.BuildId == deployment.CurrentBuildID,
+			DrainageStatus: drainageStatus(summary.DrainageStatus),
+		}
+		if summary.Version.BuildId == deployment.RampingBuildID {
+			version.RampPercentage = deployment.RampingPercentage
+		}
+
+		versionDescription, err := handle.DescribeVersion(ctx, client.WorkerDeploymentDescribeVersionOptions{BuildID: summary.Version.BuildId})
+		if err != nil {
+			return Deployment{}, fmt.Errorf("failed to describe build '%s' of worker deployment '%s': %w", summary.Version.BuildId, name, err)
+		}
+		for _, taskQueue := range versionDescription.Info.TaskQueuesInfos {
+			if taskQueue.Type == client.TaskQueueTypeWorkflow {
+				version.TaskQueues = append(version.TaskQueues, taskQueue.Name)
+			}
+		}
+		deployment.Versions = append(deployment.Versions, version)
+	}
+	return deployment, nil
+}
+
+// SetCurrentVersion routes new workflows, and the tasks of auto-upgrade workflows, to a build of the deployment.
+// It fails when the build does not poll every task queue of the current build, unless ignoreMissingTaskQueues
+func (dm *DeploymentManager) SetCurrentVersion(ctx context.Context, name string, buildID string, ignoreMissingTaskQueues bool) error {
+	handle := dm.clientManager.GetClient().WorkerDeploymentClient().GetHandle(name)
+	response, err := handle.SetCurrentVersion(ctx, client.WorkerDeploymentSetCurrentVersionOptions{
+		BuildID:                 buildID,
+		IgnoreMissingTaskQueues: ignoreMissingTaskQueues,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the current version of worker deployment '%s': %w", name, err)
+	}
+
+	previous := "unversioned"
+	if response.PreviousVersion != nil {
+		previous = response.PreviousVersion.BuildId
+	}
+	log.Printf("Worker deployment %s current version: %s (was %s)", name, buildID, previous)
+	return nil
+}
+
+// SetRampingVersion routes a percentage of the new workflows to a build of the deployment, e.g. a canary;
+// 0 pauses the ramp
+func (dm *DeploymentManager) SetRampingVersion(ctx context.Context, name string, buildID string, percentage float32) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("ramp percentage must be between 0 and 100, got %g", percentage)
+	}
+
+	handle := dm.clientManager.GetClient().WorkerDeploymentClient().GetHandle(name)
+	if _, err := handle.SetRampingVersion(ctx, client.WorkerDeploymentSetRampingVersionOptions{
+		BuildID:    buildID,
+		Percentage: percentage,
+	}); err != nil {
+		return fmt.Errorf("failed to set the ramping version of worker deployment '%s': %w", name, err)
+	}
+
+	log.Printf("Worker deployment %s ramping version: %s at %g%%", name, buildID, percentage)
+	return nil
+}
+
+func (dm *DeploymentManager) Close() {
+	dm.clientManager.Close()
+}
+
+func setRouting(deployment *Deployment, routing client.WorkerDeploymentRoutingConfig) {
+	if routing.CurrentVersion != nil {
+		deployment.CurrentBuildID = routing.CurrentVersion.BuildId
+	}
+	if routing.RampingVersion != nil {
+		deployment.RampingBuildID = routing.RampingVersion.BuildId
+		deployment.RampingPercentage = routing.RampingVersionPercentage
+	}
+}
+
+func drainageStatus(status client.WorkerDeploymentVersionDrainageStatus) string {
+	switch status {
+	case client.WorkerDeploymentVersionDrainageStatusDraining:
+		return "draining"
+	case client.WorkerDeploymentVersionDrainageStatusDrained:
+		return "drained"
+	default:
+		return ""
+	}
+}
@@ -2,15 +2,70 @@ package temporal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// namespacePageSize is how many namespaces ListNamespaces reads per request
+const namespacePageSize = 100
+
+// NamespaceDescription describes a namespace, its configuration and its custom search attributes
+type NamespaceDescription struct {
+	Name               string            `json:"name"`
+	ID                 string            `json:"id"`
+	State              string            `json:"state"`
+	Description        string            `json:"description,omitempty"`
+	OwnerEmail         string            `json:"ownerEmail,omitempty"`
+	Data               map[string]string `json:"data,omitempty"`
+	Retention          time.Duration     `json:"retention"`
+	HistoryArchival    Archival          `json:"historyArchival"`
+	VisibilityArchival Archival          `json:"visibilityArchival"`
+	Global             bool              `json:"global"`
+	ActiveCluster      string            `json:"activeCluster,omitempty"`
+	SearchAttributes   map[string]string `json:"searchAttributes,omitempty"` // custom search attribute name to type
+}
+
+// Archival is the archival state of a namespace's histories or visibility records
+type Archival struct {
+	State string `json:"state"`
+	URI   string `json:"uri,omitempty"`
+}
+
+// NamespaceUpdate changes a namespace; nil fields are left unchanged and Data is merged into the existing data
+type NamespaceUpdate struct {
+	Description *string
+	OwnerEmail  *string
+	Retention   *time.Duration
+	Data        map[string]string
+}
+
+// NamespaceFilter selects the namespaces ListNamespaces returns; empty fields match every namespace
+type NamespaceFilter struct {
+	Prefix string // name prefix
+	State  string // Registered, Deprecated or Deleted, case insensitive; deleted namespaces are only listed on request
+}
+
+// NamespaceSummary describes a namespace in a listing
+type NamespaceSummary struct {
+	Name        string        `json:"name"`
+	ID          string        `json:"id"`
+	State       string        `json:"state"`
+	Description string        `json:"description,omitempty"`
+	OwnerEmail  string        `json:"ownerEmail,omitempty"`
+	Retention   time.Duration `json:"retention"`
+}
+
 type NamespaceManager struct {
 	adminClient *ClientManager
 }
@@ -21,12 +76,11 @@ func NewNamespaceManager(options client.Options) *NamespaceManager {
 	}
 }
 
-func (nm *NamespaceManager) RegisterNamespace(namespaceName string, description string, retentionDays int) error {
-	defer nm.adminClient.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+func (nm *NamespaceManager) Close() {
+	nm.adminClient.Close()
+}
 
+func (nm *NamespaceManager) RegisterNamespace(ctx context.Context, namespaceName string, description string, retentionDays int) error {
 	if retentionDays <= 0 {
 		retentionDays = 7 // Default to 7 days
 	}
@@ -47,22 +101,169 @@ func (nm *NamespaceManager) RegisterNamespace(namespaceName string, description
 	return nil
 }
 
-func (nm *NamespaceManager) ListNamespaces() ([]string, error) {
-	defer nm.adminClient.Close()
+// ListNamespaces returns every namespace matching the filter, reading all the pages of the server's list
+func (nm *NamespaceManager) ListNamespaces(ctx context.Context, filter NamespaceFilter) ([]NamespaceSummary, error) {
+	request := &workflowservice.ListNamespacesRequest{
+		PageSize: namespacePageSize,
+		NamespaceFilter: &namespacepb.NamespaceFilter{
+			IncludeDeleted: strings.EqualFold(filter.State, "deleted"),
+		},
+	}
+
+	var namespaces []NamespaceSummary
+	for {
+		response, err := nm.adminClient.GetClient().WorkflowService().ListNamespaces(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+
+		for _, ns := range response.GetNamespaces() {
+			info := ns.GetNamespaceInfo()
+			summary := NamespaceSummary{
+				Name:        info.GetName(),
+				ID:          info.GetId(),
+				State:       strings.TrimPrefix(info.GetState().String(), "NAMESPACE_STATE_"),
+				Description: info.GetDescription(),
+				OwnerEmail:  info.GetOwnerEmail(),
+				Retention:   ns.GetConfig().GetWorkflowExecutionRetentionTtl().AsDuration(),
+			}
+			if !strings.HasPrefix(summary.Name, filter.Prefix) {
+				continue
+			}
+			if filter.State != "" && !strings.EqualFold(summary.State, filter.State) {
+				continue
+			}
+			namespaces = append(namespaces, summary)
+		}
+
+		if len(response.GetNextPageToken()) == 0 {
+			return namespaces, nil
+		}
+		request.NextPageToken = response.GetNextPageToken()
+	}
+}
+
+// DescribeNamespace returns the configuration of a namespace with its custom search attributes
+func (nm *NamespaceManager) DescribeNamespace(ctx context.Context, namespaceName string) (NamespaceDescription, error) {
+	return nm.describe(ctx, namespaceName)
+}
+
+// UpdateNamespace changes the description, owner, retention or custom data of a namespace
+func (nm *NamespaceManager) UpdateNamespace(ctx context.Context, namespaceName string, update NamespaceUpdate) (NamespaceDescription, error) {
+	request := &workflowservice.UpdateNamespaceRequest{
+		Namespace: namespaceName,
+		UpdateInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: update.Data,
+		},
+	}
+	if update.Description != nil {
+		request.UpdateInfo.Description = *update.Description
+	}
+	if update.OwnerEmail != nil {
+		request.UpdateInfo.OwnerEmail = *update.OwnerEmail
+	}
+	if update.Retention != nil {
+		if *update.Retention < 24*time.Hour {
+			return NamespaceDescription{}, fmt.Errorf("retention of namespace '%s' must be at least 1 day", namespaceName)
+		}
+		request.Config = &namespacepb.NamespaceConfig{WorkflowExecutionRetentionTtl: durationpb.New(*update.Retention)}
+	}
+
+	if _, err := nm.adminClient.GetClient().WorkflowService().UpdateNamespace(ctx, request); err != nil {
+		return NamespaceDescription{}, fmt.Errorf("failed to update namespace '%s': %w", namespaceName, err)
+	}
+
+	log.Printf("Successfully updated namespace: %s", namespaceName)
+	return nm.describe(ctx, namespaceName)
+}
+
+// DeprecateNamespace stops new workflows from starting in a namespace; running workflows go on
+func (nm *NamespaceManager) DeprecateNamespace(ctx context.Context, namespaceName string) error {
+	request := &workflowservice.UpdateNamespaceRequest{
+		Namespace:  namespaceName,
+		UpdateInfo: &namespacepb.UpdateNamespaceInfo{State: enumspb.NAMESPACE_STATE_DEPRECATED},
+	}
+	if _, err := nm.adminClient.GetClient().WorkflowService().UpdateNamespace(ctx, request); err != nil {
+		return fmt.Errorf("failed to deprecate namespace '%s': %w", namespaceName, err)
+	}
+
+	log.Printf("Successfully deprecated namespace: %s", namespaceName)
+	return nil
+}
+
+// DeleteNamespace deletes a namespace with all its workflows through the operator service
+func (nm *NamespaceManager) DeleteNamespace(ctx context.Context, namespaceName string) error {
+	request := &operatorservice.DeleteNamespaceRequest{
+		Namespace: namespaceName,
+	}
+	if _, err := nm.adminClient.GetClient().OperatorService().DeleteNamespace(ctx, request); err != nil {
+		return fmt.Errorf("failed to delete namespace '%s': %w", namespaceName, err)
+	}
+
+	log.Printf("Successfully deleted namespace: %s", namespaceName)
+	return nil
+}
+
+// AddSearchAttributes adds custom search attributes, name to type, to a namespace through the operator service
+func (nm *NamespaceManager) AddSearchAttributes(ctx context.Context, namespaceName string, searchAttributes map[string]enumspb.IndexedValueType) error {
+	request := &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespaceName,
+		SearchAttributes: searchAttributes,
+	}
+	if _, err := nm.adminClient.GetClient().OperatorService().AddSearchAttributes(ctx, request); err != nil {
+		return fmt.Errorf("failed to add search attributes to namespace '%s': %w", namespaceName, err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	log.Printf("Successfully added %d search attributes to namespace: %s", len(searchAttributes), namespaceName)
+	return nil
+}
+
+// IsNamespaceNotFound reports whether a request failed because the namespace does not exist
+func IsNamespaceNotFound(err error) bool {
+	var namespaceNotFound *serviceerror.NamespaceNotFound
+	return errors.As(err, &namespaceNotFound) || IsNotFound(err)
+}
 
-	request := &workflowservice.ListNamespacesRequest{}
-	response, err := nm.adminClient.GetClient().WorkflowService().ListNamespaces(ctx, request)
+func (nm *NamespaceManager) describe(ctx context.Context, namespaceName string) (NamespaceDescription, error) {
+	response, err := nm.adminClient.GetClient().WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespaceName,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		return NamespaceDescription{}, fmt.Errorf("failed to describe namespace '%s': %w", namespaceName, err)
 	}
 
-	var namespaces []string
-	for _, ns := range response.Namespaces {
-		namespaces = append(namespaces, ns.NamespaceInfo.Name)
+	info, config := response.GetNamespaceInfo(), response.GetConfig()
+	description := NamespaceDescription{
+		Name:        info.GetName(),
+		ID:          info.GetId(),
+		State:       strings.TrimPrefix(info.GetState().String(), "NAMESPACE_STATE_"),
+		Description: info.GetDescription(),
+		OwnerEmail:  info.GetOwnerEmail(),
+		Data:        info.GetData(),
+		Retention:   config.GetWorkflowExecutionRetentionTtl().AsDuration(),
+		HistoryArchival: Archival{
+			State: strings.TrimPrefix(config.GetHistoryArchivalState().String(), "ARCHIVAL_STATE_"),
+			URI:   config.GetHistoryArchivalUri(),
+		},
+		VisibilityArchival: Archival{
+			State: strings.TrimPrefix(config.GetVisibilityArchivalState().String(), "ARCHIVAL_STATE_"),
+			URI:   config.GetVisibilityArchivalUri(),
+		},
+		Global:        response.GetIsGlobalNamespace(),
+		ActiveCluster: response.GetReplicationConfig().GetActiveClusterName(),
 	}
 
-	return namespaces, nil
+	attributes, err := nm.adminClient.GetClient().OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: namespaceName,
+	})
+	if err != nil {
+		return NamespaceDescription{}, fmt.Errorf("failed to list search attributes of namespace '%s': %w", namespaceName, err)
+	}
+	for name, indexedValueType := range attributes.GetCustomAttributes() {
+		if description.SearchAttributes == nil {
+			description.SearchAttributes = map[string]string{}
+		}
+		description.SearchAttributes[name] = strings.TrimPrefix(indexedValueType.String(), "INDEXED_VALUE_TYPE_")
+	}
+	return description, nil
 }
@@ -0,0 +1,47 @@
+package temporal
+
+import (
+	"fmt"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// SearchAttributeString decodes a keyword or text search attribute, returning an empty string if it is not set
+func SearchAttributeString(searchAttributes *commonpb.SearchAttributes, name string) string {
+	var value string
+	if payload, ok := searchAttributes.GetIndexedFields()[name]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(payload, &value)
+	}
+	return value
+}
+
+// SearchAttributeStrings decodes a keyword list search attribute, returning nil if it is not set
+func SearchAttributeStrings(searchAttributes *commonpb.SearchAttributes, name string) []string {
+	var values []string
+	if payload, ok := searchAttributes.GetIndexedFields()[name]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(payload, &values)
+	}
+	return values
+}
+
+// MemoString decodes a string memo field, returning an empty string if it is not set
+func MemoString(memo *commonpb.Memo, key string) string {
+	var value string
+	if payload, ok := memo.GetFields()[key]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(payload, &value)
+	}
+	return value
+}
+
+// PayloadString decodes a payload holding a string, e.g. a group value of a count, or formats any other value
+func PayloadString(payload *commonpb.Payload) string {
+	var value any
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &value); err != nil {
+		return ""
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -5,6 +5,7 @@ import (
 
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
 )
 
 type WorkerManager struct {
@@ -12,16 +13,16 @@ type WorkerManager struct {
 	worker        worker.Worker
 }
 
-func NewWorkerManager(options client.Options, taskQueue string) *WorkerManager {
+// NewWorkerManager creates a worker for the task queue; workerOptions carries settings such as activity rate limits
+func NewWorkerManager(options client.Options, taskQueue string, workerOptions worker.Options) *WorkerManager {
 	clientManager := NewClientManager(options)
 
 	if taskQueue == "" {
 		log.Panicf("Task queue must be specified")
 	}
 
-	w := worker.New(clientManager.GetClient(), taskQueue, worker.Options{
-		EnableLoggingInReplay: true,
-	})
+	workerOptions.EnableLoggingInReplay = true
+	w := worker.New(clientManager.GetClient(), taskQueue, workerOptions)
 
 	return &WorkerManager{
 		clientManager: clientManager,
@@ -29,8 +30,11 @@ func NewWorkerManager(options client.Options, taskQueue string) *WorkerManager {
 	}
 }
 
-func (wm *WorkerManager) RegisterWorkflow(workflowFunc any) {
-	wm.worker.RegisterWorkflow(workflowFunc)
+// RegisterWorkflow registers a workflow with the versioning behavior its executions get on a versioned worker:
+// pinned executions complete on the build that started them, auto-upgrade executions move to the current build
+// and must keep old histories replaying with workflow.GetVersion. Unversioned workers ignore the behavior
+func (wm *WorkerManager) RegisterWorkflow(workflowFunc any, behavior workflow.VersioningBehavior) {
+	wm.worker.RegisterWorkflowWithOptions(workflowFunc, workflow.RegisterOptions{VersioningBehavior: behavior})
 }
 
 func (wm *WorkerManager) RegisterActivity(activityFunc any) {
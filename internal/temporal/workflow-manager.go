@@ -2,10 +2,23 @@ package temporal
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	sdktemporal "go.temporal.io/sdk/temporal"
+)
+
+// the marker workflow.GetVersion records in the history, with its change ID and version details
+const (
+	versionMarkerName     = "Version"
+	versionMarkerChangeID = "change-id"
+	versionMarkerVersion  = "version"
 )
 
 type WorkflowManager struct {
@@ -33,7 +46,11 @@ type StartWorkflowOptions struct {
 
 type ScheduleWorkflowOptions struct {
 	Specs            client.ScheduleSpec
-	RemainingActions int // number of iterations, 0 means infinite
+	RemainingActions int                           // number of iterations, 0 means infinite
+	Overlap          enumspb.ScheduleOverlapPolicy // what happens when an action is due while the last one runs, unspecified means skip
+	CatchupWindow    time.Duration                 // how late missed actions are still taken, e.g. after an outage; 0 means the server default of one year
+	PauseOnFailure   bool                          // pause the schedule when an action fails
+	Jitter           time.Duration                 // delay each action by a random duration up to this, overrides the jitter of the spec
 	StartWorkflowOptions
 }
 
@@ -47,10 +64,17 @@ func (wm *WorkflowManager) StartWorkflow(ctx context.Context, options StartWorkf
 	}
 
 	workflowOptions := client.StartWorkflowOptions{
-		ID:                    options.WorkflowID,
-		TaskQueue:             options.TaskQueue,
-		SearchAttributes:      searchAttributes,
+		ID:               options.WorkflowID,
+		TaskQueue:        options.TaskQueue,
+		SearchAttributes: searchAttributes,
+		Memo: map[string]any{
+			"businessUnit": options.BusinessUnit,
+			"priority":     options.Priority,
+			"environment":  options.Environment,
+		},
 		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY, // allows restart only if previous failed
+		// report duplicates instead of silently returning the existing run
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
 	}
 
 	return wm.clientManager.GetClient().ExecuteWorkflow(ctx, workflowOptions, workflowFunc, args...)
@@ -67,17 +91,29 @@ func (wm *WorkflowManager) StartScheduledWorkflow(ctx context.Context, options S
 		"CustomDatetimeField": time.Now(),
 	}
 
+	spec := options.Specs
+	if options.Jitter != 0 {
+		spec.Jitter = options.Jitter
+	}
+
 	workflowOptions := client.ScheduleOptions{
 		ID:               options.OrderID, // schedule ID
-		Spec:             options.Specs,
+		Spec:             spec,
 		RemainingActions: options.RemainingActions,
 		SearchAttributes: searchAttributes,
-		Overlap:          enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL,
+		Overlap:          options.Overlap,
+		CatchupWindow:    options.CatchupWindow,
+		PauseOnFailure:   options.PauseOnFailure,
 		Action: &client.ScheduleWorkflowAction{
 			ID:        options.OrderID,
 			Workflow:  workflowFunc,
 			Args:      args,
 			TaskQueue: options.TaskQueue,
+			Memo: map[string]any{
+				"businessUnit": options.BusinessUnit,
+				"priority":     options.Priority,
+				"environment":  options.Environment,
+			},
 		},
 	}
 	return scheduleClient.Create(ctx, workflowOptions)
@@ -88,10 +124,124 @@ func (wm *WorkflowManager) GetScheduleHandle(ctx context.Context, scheduleID str
 	return scheduleClient.GetHandle(ctx, scheduleID)
 }
 
+func (wm *WorkflowManager) ListSchedules(ctx context.Context, query string) ([]*client.ScheduleListEntry, error) {
+	iter, err := wm.clientManager.NewScheduleClient().List(ctx, client.ScheduleListOptions{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	var schedules []*client.ScheduleListEntry
+	for iter.HasNext() {
+		schedule, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
 func (wm *WorkflowManager) GetWorkflow(ctx context.Context, workflowID string, runID string) client.WorkflowRun {
 	return wm.clientManager.GetClient().GetWorkflow(ctx, workflowID, runID)
 }
 
+func (wm *WorkflowManager) DescribeWorkflow(ctx context.Context, workflowID string, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	return wm.clientManager.GetClient().DescribeWorkflowExecution(ctx, workflowID, runID)
+}
+
 func (wm *WorkflowManager) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg any) error {
 	return wm.clientManager.GetClient().SignalWorkflow(ctx, workflowID, runID, signalName, arg)
 }
+
+// SignalWithStartWorkflow signals a workflow, starting it first if it is not running
+func (wm *WorkflowManager) SignalWithStartWorkflow(ctx context.Context, workflowID string, taskQueue string, signalName string, signalArg any, workflowFunc any, args ...any) (client.WorkflowRun, error) {
+	options := client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: taskQueue,
+	}
+	return wm.clientManager.GetClient().SignalWithStartWorkflow(ctx, workflowID, signalName, signalArg, options, workflowFunc, args...)
+}
+
+func (wm *WorkflowManager) QueryWorkflow(ctx context.Context, workflowID string, runID string, queryType string, result any, args ...any) error {
+	response, err := wm.clientManager.GetClient().QueryWorkflow(ctx, workflowID, runID, queryType, args...)
+	if err != nil {
+		return err
+	}
+	return response.Get(result)
+}
+
+func (wm *WorkflowManager) ListWorkflows(ctx context.Context, query string, pageSize int32, nextPageToken []byte) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	return wm.clientManager.GetClient().ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Query:         query,
+		PageSize:      pageSize,
+		NextPageToken: nextPageToken,
+	})
+}
+
+// CountWorkflows counts the workflows matching a visibility query, per group when it ends with GROUP BY
+func (wm *WorkflowManager) CountWorkflows(ctx context.Context, query string) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+	return wm.clientManager.GetClient().CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Query: query,
+	})
+}
+
+// GetChangeVersions returns the version each workflow.GetVersion change ID recorded in the history of a workflow
+func (wm *WorkflowManager) GetChangeVersions(ctx context.Context, workflowID string, runID string) (map[string]int, error) {
+	versions := map[string]int{}
+	iter := wm.clientManager.GetClient().GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		attributes := event.GetMarkerRecordedEventAttributes()
+		if attributes == nil || attributes.GetMarkerName() != versionMarkerName {
+			continue
+		}
+		var (
+			changeID string
+			version  int
+		)
+		details := attributes.GetDetails()
+		if err := converter.GetDefaultDataConverter().FromPayloads(details[versionMarkerChangeID], &changeID); err != nil {
+			return nil, fmt.Errorf("failed to decode version marker of workflow %s: %w", workflowID, err)
+		}
+		if err := converter.GetDefaultDataConverter().FromPayloads(details[versionMarkerVersion], &version); err != nil {
+			return nil, fmt.Errorf("failed to decode version marker of workflow %s: %w", workflowID, err)
+		}
+		versions[changeID] = version
+	}
+	return versions, nil
+}
+
+// GetWorkflowInput decodes the arguments a workflow was started with from the first event of its history
+func (wm *WorkflowManager) GetWorkflowInput(ctx context.Context, workflowID string, runID string, valuePtrs ...any) error {
+	iter := wm.clientManager.GetClient().GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	if !iter.HasNext() {
+		return fmt.Errorf("workflow %s has no history", workflowID)
+	}
+
+	event, err := iter.Next()
+	if err != nil {
+		return err
+	}
+
+	attributes := event.GetWorkflowExecutionStartedEventAttributes()
+	if attributes == nil {
+		return fmt.Errorf("first event of workflow %s is not a workflow execution started event", workflowID)
+	}
+	return converter.GetDefaultDataConverter().FromPayloads(attributes.GetInput(), valuePtrs...)
+}
+
+// IsAlreadyStarted reports whether a start failed because the workflow or schedule ID is already in use
+func IsAlreadyStarted(err error) bool {
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	return errors.As(err, &alreadyStarted) || errors.Is(err, sdktemporal.ErrScheduleAlreadyRunning)
+}
+
+// IsNotFound reports whether a request failed because the workflow or schedule does not exist
+func IsNotFound(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.As(err, &notFound)
+}
@@ -0,0 +1,245 @@
+package versions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"temporal-playground/internal/temporal"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/workflow"
+)
+
+const pageSize = 100
+
+// changeVersionAttribute is the keyword list search attribute workflow.GetVersion keeps the recorded versions in,
+// as "<change ID>-<version>"
+const changeVersionAttribute = "TemporalChangeVersion"
+
+// ReportOptions selects the workflows whose GetVersion markers are scanned
+type ReportOptions struct {
+	WorkflowTypes []string      // all workflow types when empty
+	ChangeIDs     []string      // all change IDs when empty
+	ClosedWithin  time.Duration // closed workflows are scanned as long as they can still be reset or queried, i.e. their retention
+	Limit         int           // most workflows scanned, 0 means all
+}
+
+// Report tells which versions of each change ID are still in use by open and recently closed workflows
+type Report struct {
+	Scanned   int            `json:"scanned"`
+	Truncated bool           `json:"truncated"` // the scan stopped at the limit
+	Since     time.Time      `json:"since"`     // closed workflows are scanned from
+	Changes   []ChangeReport `json:"changes"`
+}
+
+// ChangeReport describes the versions of one change ID in one workflow type
+type ChangeReport struct {
+	WorkflowType string         `json:"workflowType"`
+	ChangeID     string         `json:"changeID"`
+	MaxVersion   int            `json:"maxVersion"` // highest recorded version; maxSupported must never be lowered below it
+	Versions     []VersionUsage `json:"versions"`
+	Advice       []string       `json:"advice"`
+}
+
+// VersionUsage counts the workflows on one version of a change ID. workflow.DefaultVersion counts the workflows
+// without a marker for the change ID: started before the change, or not past the GetVersion call yet
+type VersionUsage struct {
+	Version int `json:"version"`
+	Open    int `json:"open"`
+	Closed  int `json:"closed"`
+}
+
+// Execution is one scanned workflow and the versions its history recorded
+type Execution struct {
+	WorkflowType string
+	Open         bool
+	Versions     map[string]int
+}
+
+// Reporter scans the workflows of the namespace through visibility and reads their GetVersion markers from the
+// histories of the workflows whose TemporalChangeVersion search attribute holds a change ID of the report
+type Reporter struct {
+	workflowManager *temporal.WorkflowManager
+}
+
+func NewReporter(workflowManager *temporal.WorkflowManager) *Reporter {
+	return &Reporter{
+		workflowManager: workflowManager,
+	}
+}
+
+// Report scans the open workflows and the workflows closed within options.ClosedWithin
+func (r *Reporter) Report(ctx context.Context, options ReportOptions) (Report, error) {
+	since := time.Now().Add(-options.ClosedWithin).UTC()
+	query := fmt.Sprintf("(ExecutionStatus = 'Running' OR CloseTime >= '%s')", since.Format(time.RFC3339))
+	if len(options.WorkflowTypes) > 0 {
+		query += fmt.Sprintf(" AND WorkflowType IN ('%s')", strings.Join(options.WorkflowTypes, "', '"))
+	}
+
+	var (
+		executions    []Execution
+		truncated     bool
+		nextPageToken []byte
+	)
+	for {
+		response, err := r.workflowManager.ListWorkflows(ctx, query, pageSize, nextPageToken)
+		if err != nil {
+			return Report{}, fmt.Errorf("failed to list workflows: %w", err)
+		}
+		for _, info := range response.GetExecutions() {
+			if options.Limit > 0 && len(executions) == options.Limit {
+				truncated = true
+				break
+			}
+			// only the histories of workflows that recorded one of the change IDs are read; the others are on
+			// workflow.DefaultVersion of every change ID
+			versions := map[string]int{}
+			if recordsChange(temporal.SearchAttributeStrings(info.GetSearchAttributes(), changeVersionAttribute), options.ChangeIDs) {
+				execution := info.GetExecution()
+				if versions, err = r.workflowManager.GetChangeVersions(ctx, execution.GetWorkflowId(), execution.GetRunId()); err != nil {
+					return Report{}, fmt.Errorf("failed to read the history of workflow %s: %w", execution.GetWorkflowId(), err)
+				}
+			}
+			executions = append(executions, Execution{
+				WorkflowType: info.GetType().GetName(),
+				Open:         info.GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				Versions:     versions,
+			})
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if truncated || len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	report := BuildReport(executions, options.ChangeIDs)
+	report.Since = since
+	report.Truncated = truncated
+	return report, nil
+}
+
+// recordsChange reports whether the TemporalChangeVersion values name one of the change IDs, or any change ID
+// when none are given
+func recordsChange(changeVersions []string, changeIDs []string) bool {
+	if len(changeIDs) == 0 {
+		return len(changeVersions) > 0
+	}
+	for _, changeVersion := range changeVersions {
+		if i := strings.LastIndex(changeVersion, "-"); i > 0 && slices.Contains(changeIDs, changeVersion[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildReport counts the versions of every change ID recorded by the executions of a workflow type, and advises
+// which GetVersion branches can be dropped
+func BuildReport(executions []Execution, changeIDs []string) Report {
+	type change struct{ workflowType, changeID string }
+	usage := map[change]map[int]*VersionUsage{}
+
+	// the change IDs each workflow type recorded in any of its executions
+	typeChanges := map[string]map[string]bool{}
+	for _, execution := range executions {
+		for changeID := range execution.Versions {
+			if len(changeIDs) > 0 && !slices.Contains(changeIDs, changeID) {
+				continue
+			}
+			if typeChanges[execution.WorkflowType] == nil {
+				typeChanges[execution.WorkflowType] = map[string]bool{}
+			}
+			typeChanges[execution.WorkflowType][changeID] = true
+		}
+	}
+
+	for _, execution := range executions {
+		for changeID := range typeChanges[execution.WorkflowType] {
+			key := change{execution.WorkflowType, changeID}
+			if usage[key] == nil {
+				usage[key] = map[int]*VersionUsage{}
+			}
+
+			version, ok := execution.Versions[changeID]
+			if !ok {
+				version = int(workflow.DefaultVersion)
+			}
+			if usage[key][version] == nil {
+				usage[key][version] = &VersionUsage{Version: version}
+			}
+			if execution.Open {
+				usage[key][version].Open++
+			} else {
+				usage[key][version].Closed++
+			}
+		}
+	}
+
+	report := Report{Scanned: len(executions)}
+	for key, versions := range usage {
+		changeReport := ChangeReport{
+			WorkflowType: key.workflowType,
+			ChangeID:     key.changeID,
+		}
+		for _, version := range slices.Sorted(maps.Keys(versions)) {
+			changeReport.Versions = append(changeReport.Versions, *versions[version])
+		}
+		changeReport.MaxVersion = changeReport.Versions[len(changeReport.Versions)-1].Version
+		changeReport.Advice = advise(changeReport)
+		report.Changes = append(report.Changes, changeReport)
+	}
+	slices.SortFunc(report.Changes, func(a, b ChangeReport) int {
+		return strings.Compare(a.WorkflowType+"/"+a.ChangeID, b.WorkflowType+"/"+b.ChangeID)
+	})
+	return report
+}
+
+// advise tells which branches of a change ID are still needed. A branch is needed while an open workflow replays
+// through it; closed workflows only replay when they are reset or queried, until retention removes them
+func advise(change ChangeReport) []string {
+	var (
+		advice     []string
+		openOlder  bool
+		usedOlder  bool
+		minVersion = change.MaxVersion
+	)
+	for _, usage := range change.Versions {
+		if usage.Version == change.MaxVersion {
+			continue
+		}
+		name := versionName(usage.Version)
+		switch {
+		case usage.Open > 0:
+			openOlder = true
+			minVersion = min(minVersion, usage.Version)
+			advice = append(advice, fmt.Sprintf("keep %s: %d open workflows replay through it", name, usage.Open))
+		default:
+			usedOlder = true
+			advice = append(advice, fmt.Sprintf("%s can be dropped: no open workflows use it; the %d closed ones on it could then no longer be reset or queried",
+				name, usage.Closed))
+		}
+	}
+
+	switch {
+	case openOlder && minVersion == int(workflow.DefaultVersion):
+		advice = append(advice, "minSupported must stay workflow.DefaultVersion")
+	case openOlder:
+		advice = append(advice, fmt.Sprintf("minSupported can be raised to %d, dropping the branches below it", minVersion))
+	case usedOlder:
+		advice = append(advice, fmt.Sprintf("once the closed workflows pass retention, raise minSupported to %d and keep only its branch", change.MaxVersion))
+	default:
+		advice = append(advice, fmt.Sprintf("every workflow is on version %d: raise minSupported to %d and keep only its branch; "+
+			"keep the GetVersion call, it is Go's equivalent of a deprecated patch", change.MaxVersion, change.MaxVersion))
+	}
+	return append(advice, fmt.Sprintf("never lower maxSupported below %d", change.MaxVersion))
+}
+
+func versionName(version int) string {
+	if version == int(workflow.DefaultVersion) {
+		return "the default version branch"
+	}
+	return fmt.Sprintf("version %d", version)
+}
@@ -0,0 +1,190 @@
+package workflows
+
+import (
+	"errors"
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	MaxBreakerHistoryLength = 10000 // continue-as-new before the history grows past this
+	MinCircuitOpenWait      = 5 * time.Second
+	MaxCircuitOpenWaits     = 60 // waits for an open breaker before queryOrder returns the CircuitOpen error
+)
+
+// CircuitBreaker keeps the closed/open/half-open state of one upstream provider, shared by every worker.
+// QueryOrder activities report upstream failures, and the successes that follow them, with a signal and read the
+// state with a query, cached briefly by each worker;
+// the workflow ID is "circuit-breaker-<provider>" and it runs until terminated
+func CircuitBreaker(ctx workflow.Context, state models.BreakerState) error {
+	var (
+		logger        = workflow.GetLogger(ctx)
+		reportChannel = workflow.GetSignalChannel(ctx, SignalBreakerReport)
+		resetChannel  = workflow.GetSignalChannel(ctx, SignalBreakerReset)
+	)
+
+	if state.State == "" {
+		state.State = models.BreakerClosed
+		state.UpdatedAt = workflow.Now(ctx)
+	}
+
+	if err := workflow.SetQueryHandler(ctx, QueryBreakerState, func() (models.BreakerState, error) {
+		return state, nil
+	}); err != nil {
+		return err
+	}
+
+	open := func(now time.Time) {
+		retryAt := now.Add(state.Policy.OpenDuration)
+		state.State = models.BreakerOpen
+		state.OpenedAt = &now
+		state.RetryAt = &retryAt
+		state.HalfOpenSuccesses = 0
+		logger.Warn("Circuit breaker opened", "provider", state.Provider, "retryAt", retryAt, "lastError", state.LastError)
+	}
+	closeBreaker := func() {
+		state.State = models.BreakerClosed
+		state.ConsecutiveFailures = 0
+		state.HalfOpenSuccesses = 0
+		state.OpenedAt = nil
+		state.RetryAt = nil
+		state.ForcedBy = ""
+		logger.Info("Circuit breaker closed", "provider", state.Provider)
+	}
+
+	applyReport := func(report models.BreakerReport) {
+		if report.Policy.FailureThreshold > 0 {
+			state.Policy = report.Policy
+		}
+
+		switch {
+		case report.Success && state.State == models.BreakerClosed:
+			state.ConsecutiveFailures = 0
+		case report.Success && state.State == models.BreakerHalfOpen:
+			state.HalfOpenSuccesses++
+			if state.HalfOpenSuccesses >= max(state.Policy.HalfOpenSuccesses, 1) {
+				closeBreaker()
+			}
+		case !report.Success:
+			state.LastError = report.Error
+			state.ConsecutiveFailures++
+			if state.State == models.BreakerHalfOpen ||
+				(state.State == models.BreakerClosed && state.ConsecutiveFailures >= max(state.Policy.FailureThreshold, 1)) {
+				open(workflow.Now(ctx))
+			}
+		}
+		// results reported while open come from calls started before it opened and are ignored
+	}
+	applyReset := func(reset models.BreakerReset) {
+		logger.Info("Circuit breaker reset by operator", "provider", state.Provider, "state", reset.State, "operator", reset.Operator, "reason", reset.Reason)
+
+		if reset.State == models.BreakerOpen {
+			state.LastError = "forced open by " + reset.Operator
+			state.ForcedBy = reset.Operator
+			open(workflow.Now(ctx))
+			state.RetryAt = nil
+			return
+		}
+		closeBreaker()
+	}
+
+	for {
+		var (
+			selector              = workflow.NewSelector(ctx)
+			timerCtx, cancelTimer = workflow.WithCancel(ctx)
+		)
+
+		selector.AddReceive(reportChannel, func(c workflow.ReceiveChannel, more bool) {
+			var report models.BreakerReport
+			c.Receive(ctx, &report)
+			applyReport(report)
+		})
+		selector.AddReceive(resetChannel, func(c workflow.ReceiveChannel, more bool) {
+			var reset models.BreakerReset
+			c.Receive(ctx, &reset)
+			applyReset(reset)
+		})
+		if state.State == models.BreakerOpen && state.RetryAt != nil {
+			selector.AddFuture(workflow.NewTimer(timerCtx, max(state.RetryAt.Sub(workflow.Now(ctx)), 0)), func(f workflow.Future) {
+				state.State = models.BreakerHalfOpen
+				state.HalfOpenSuccesses = 0
+				logger.Info("Circuit breaker half-open - letting calls probe the upstream", "provider", state.Provider)
+			})
+		}
+
+		selector.Select(ctx)
+		cancelTimer()
+		state.UpdatedAt = workflow.Now(ctx)
+
+		info := workflow.GetInfo(ctx)
+		if info.GetContinueAsNewSuggested() || info.GetCurrentHistoryLength() > MaxBreakerHistoryLength {
+			// a busy breaker always has signals buffered, so apply them here instead of waiting for a quiet moment
+			for {
+				var report models.BreakerReport
+				if !reportChannel.ReceiveAsync(&report) {
+					break
+				}
+				applyReport(report)
+			}
+			for {
+				var reset models.BreakerReset
+				if !resetChannel.ReceiveAsync(&reset) {
+					break
+				}
+				applyReset(reset)
+			}
+			return workflow.NewContinueAsNewError(ctx, CircuitBreaker, state)
+		}
+	}
+}
+
+// queryOrder runs the QueryOrder activity, first waiting while the business unit or environment is paused.
+// While the upstream's circuit breaker is open the activity fails fast without using up its attempts,
+// and the workflow sleeps on a durable timer until the breaker lets calls through again.
+// Both waits are bounded: after MaxCircuitOpenWaits, or when the server suggests continue-as-new, the
+// CircuitOpen or ProcessingPaused error is returned so the caller escalates the order as on any other failure.
+// The checks are versioned, so workflows that queried the order before them still replay
+func queryOrder(ctx workflow.Context, orderID string, businessUnit string, environment string) error {
+	var orderActivity *activities.OrderActivities
+	limited := workflow.GetVersion(ctx, "query-order-wait-limit", workflow.DefaultVersion, 1) == 1
+
+	for waits := 0; ; waits++ {
+		if workflow.GetVersion(ctx, "query-order-pause", workflow.DefaultVersion, 1) == 1 {
+			if err := waitWhilePaused(ctx, businessUnit, environment, limited); err != nil {
+				return err
+			}
+		}
+
+		err := workflow.ExecuteActivity(ctx, orderActivity.QueryOrder, orderID, businessUnit).Get(ctx, nil)
+
+		var applicationErr *temporal.ApplicationError
+		if !errors.As(err, &applicationErr) || applicationErr.Type() != activities.ErrTypeCircuitOpen ||
+			workflow.GetVersion(ctx, "query-order-circuit-wait", workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+			return err
+		}
+		if limited && (waits >= MaxCircuitOpenWaits || workflow.GetInfo(ctx).GetContinueAsNewSuggested()) {
+			return err
+		}
+
+		// wait until the breaker turns half-open, or a full open period when it was forced open
+		wait := MinCircuitOpenWait
+		var state models.BreakerState
+		if applicationErr.HasDetails() && applicationErr.Details(&state) == nil {
+			if state.RetryAt != nil {
+				wait = max(state.RetryAt.Sub(workflow.Now(ctx)), MinCircuitOpenWait)
+			} else {
+				wait = max(state.Policy.OpenDuration, MinCircuitOpenWait)
+			}
+		}
+
+		workflow.GetLogger(ctx).Info("Upstream circuit breaker open - waiting before querying the order",
+			"orderID", orderID, "provider", state.Provider, "wait", wait.String())
+		if err := workflow.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package workflows
+
+// Signal names shared by the order workflows and the clients that resolve them
+const (
+	SignalResolveStaleWorkflow = "resolve-stale-workflow"
+	SignalResolveManualOrder   = "resolve-manual-order"
+	SignalManualCaseAction     = "manual-case-action"
+	SignalBreakerReport        = "breaker-report"
+	SignalBreakerReset         = "breaker-reset"
+	SignalProcessingControl    = "processing-control"
+	SignalPauseWaiter          = "pause-waiter"
+	SignalResumeProcessing     = "resume-processing"
+)
+
+// Query names exposed by the order workflows
+const (
+	QueryManualSLAState  = "sla-state"
+	QueryManualCaseState = "case-state"
+	QueryManualRequest   = "manual-request" // the manual stage of OrderLifecycle, which has no ManualHandleRequest input
+	QueryBreakerState    = "breaker-state"
+	QueryPausedScopes    = "paused-scopes"
+	QueryDunningState    = "dunning-state"
+)
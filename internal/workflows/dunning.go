@@ -0,0 +1,150 @@
+package workflows
+
+import (
+	"errors"
+	"strconv"
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DefaultDunningSchedule retries a failed recurring charge 1, 3 and 7 days after it failed
+var DefaultDunningSchedule = []time.Duration{24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+// RecurringPaymentDunning retries a failed recurring charge on the contract's dunning schedule, telling the customer
+// after every failure. Retries charge the term of the failed payment, so the customer is charged at most once for it.
+// When the last retry fails the recurring payment is paused and its contract marked delinquent.
+// The last notice sent is returned by the dunning state query
+func RecurringPaymentDunning(ctx workflow.Context, request models.DunningRequest) (string, error) {
+	var (
+		logger              = workflow.GetLogger(ctx)
+		contract            = request.Contract
+		recurringActivities *activities.RecurringPaymentActivities
+		schedule            = contract.DunningSchedule
+		lastNotice          models.DunningNotice
+	)
+	if len(schedule) == 0 {
+		schedule = DefaultDunningSchedule
+	}
+
+	if err := workflow.SetQueryHandler(ctx, QueryDunningState, func() (models.DunningNotice, error) {
+		return lastNotice, nil
+	}); err != nil {
+		return "", err
+	}
+
+	notify := func(stage string, retries int, reason string) {
+		lastNotice = models.DunningNotice{
+			NoticeID:     workflow.GetInfo(ctx).WorkflowExecution.ID + "/" + strconv.Itoa(retries),
+			ConsentID:    contract.ConsentID,
+			CustomerID:   contract.CustomerID,
+			BusinessUnit: contract.BusinessUnit,
+			PaymentID:    request.PaymentID,
+			Amount:       contract.Amount,
+			Stage:        stage,
+			Retries:      retries,
+			Reason:       reason,
+			SentAt:       workflow.Now(ctx),
+		}
+		if retries < len(schedule) && stage != models.DunningRecovered {
+			nextRetryAt := request.FailedAt.Add(schedule[retries])
+			lastNotice.NextRetryAt = &nextRetryAt
+		}
+		notifyCustomer(ctx, lastNotice)
+	}
+
+	notify(models.DunningPaymentFailed, 0, request.FailureReason)
+
+	chargeCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 1 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    2,
+			InitialInterval:    1 * time.Second,
+			BackoffCoefficient: 2.0,
+		},
+	})
+
+	reason := request.FailureReason
+	for retry, delay := range schedule {
+		if err := workflow.Sleep(ctx, max(request.FailedAt.Add(delay).Sub(workflow.Now(ctx)), 0)); err != nil {
+			return "", err
+		}
+
+		var result string
+		err := workflow.ExecuteActivity(chargeCtx, recurringActivities.ChargeRecurringPayment, contract, request.Term).Get(ctx, &result)
+		if err == nil {
+			logger.Info("Recurring payment recovered by dunning", "consentID", contract.ConsentID, "retries", retry+1)
+			notify(models.DunningRecovered, retry+1, "")
+			return result, nil
+		}
+
+		reason = err.Error()
+		logger.Warn("Dunning retry failed", "consentID", contract.ConsentID, "retry", retry+1, "error", reason)
+		if retry+1 < len(schedule) {
+			notify(models.DunningRetryFailed, retry+1, reason)
+		}
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 1 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Minute,
+		},
+	})
+	if err := workflow.ExecuteActivity(ctx, recurringActivities.MarkContractDelinquent, contract.ConsentID, reason).Get(ctx, nil); err != nil {
+		logger.Error("Failed to mark recurring payment delinquent", "consentID", contract.ConsentID, "error", err.Error())
+		return "", err
+	}
+	notify(models.DunningDelinquent, len(schedule), reason)
+
+	return "", temporal.NewNonRetryableApplicationError("recurring payment "+contract.ConsentID+" is delinquent: "+reason, models.ContractDelinquent, nil)
+}
+
+// notifyCustomer sends a dunning notice to the customer. Delivery is retried by the activity retry policy;
+// if it still fails dunning goes on
+func notifyCustomer(ctx workflow.Context, notice models.DunningNotice) {
+	var notifier *activities.Notifier
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 2 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Minute,
+			MaximumAttempts:    10,
+		},
+	})
+	if err := workflow.ExecuteActivity(ctx, notifier.NotifyCustomer, notice).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to notify customer", "consentID", notice.ConsentID, "stage", notice.Stage, "error", err.Error())
+	}
+}
+
+// startDunning starts the dunning workflow of a failed recurring charge. It outlives the payment workflow,
+// which only waits for it to start
+func startDunning(ctx workflow.Context, contract models.RecurringPaymentContract, term models.ChargeTerm, chargeErr error) error {
+	var applicationErr *temporal.ApplicationError
+	if errors.As(chargeErr, &applicationErr) && applicationErr.Type() == activities.ErrTypeInvalidContract {
+		// retrying cannot fix the contract
+		return nil
+	}
+
+	info := workflow.GetInfo(ctx)
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:        "dunning-" + info.WorkflowExecution.ID,
+		ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+	})
+	dunning := workflow.ExecuteChildWorkflow(childCtx, RecurringPaymentDunning, models.DunningRequest{
+		Contract:      contract,
+		Term:          term,
+		PaymentID:     info.WorkflowExecution.ID,
+		FailedAt:      workflow.Now(ctx),
+		FailureReason: chargeErr.Error(),
+	})
+	return dunning.GetChildWorkflowExecution().Get(ctx, nil)
+}
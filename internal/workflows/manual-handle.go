@@ -1,6 +1,8 @@
 package workflows
 
 import (
+	"fmt"
+	"strings"
 	"temporal-playground/internal/activities"
 	"temporal-playground/internal/models"
 	"time"
@@ -10,49 +12,254 @@ import (
 )
 
 // ManualHandleOrderWorkflow handles orders that require manual intervention
-// This workflow waits indefinitely until a manual signal is received
+// This workflow waits until a manual signal is received, sending reminder and escalation
+// alerts as the SLA warning and breach deadlines pass, and optionally auto-resolves after a hard timeout.
+// Operators claim, reassign and comment on the case through case action signals, all kept in an audit trail
 func ManualHandleOrder(ctx workflow.Context, request models.ManualHandleRequest) error {
 
 	var (
 		logger         = workflow.GetLogger(ctx)
 		selector       = workflow.NewSelector(ctx)
-		resolveChannel = workflow.GetSignalChannel(ctx, "resolve-manual-order")
+		resolveChannel = workflow.GetSignalChannel(ctx, SignalResolveManualOrder)
+		caseChannel    = workflow.GetSignalChannel(ctx, SignalManualCaseAction)
 		resolveSignal  string
+		resolvedBy     = "manual-intervention"
+		firedLevel     string
+		caseState      = models.ManualCaseState{OrderID: request.OrderID}
 	)
 
-	// Wait indefinitely for manual resolution signal
+	audit := func(action string, operator string, detail string, rejected bool) {
+		auditCase(ctx, &caseState, action, operator, detail, rejected)
+	}
+	audit(models.CaseActionOpen, "system", request.FailureReason, false)
+
+	// cases opened before claims were enforced let any operator reassign or resolve them
+	enforceClaims := workflow.GetVersion(ctx, "manual-case-claims", workflow.DefaultVersion, 1) == 1
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 5,
+			BackoffCoefficient: 1.5,
+			MaximumInterval:    time.Minute * 2,
+			MaximumAttempts:    3,
+		},
+	})
+
+	// Capture the SLA policy once so later changes to the policy tables do not break replay.
+	// Cases opened before SLAs keep waiting for their resolution without a policy or timers
+	policy := models.SLAPolicy{}
+	if workflow.GetVersion(ctx, "manual-handle-sla", workflow.DefaultVersion, 1) == 1 {
+		if request.SLA != nil {
+			policy = *request.SLA
+		} else if err := workflow.SideEffect(ctx, func(ctx workflow.Context) any {
+			return LookupSLAPolicy(request.BusinessUnit, request.Priority)
+		}).Get(&policy); err != nil {
+			return err
+		}
+	}
+
+	slaState := models.SLAState{
+		OrderID:      request.OrderID,
+		BusinessUnit: request.BusinessUnit,
+		Priority:     request.Priority,
+		Policy:       policy,
+		Status:       models.SLAStatusWithin,
+		EnteredAt:    workflow.Now(ctx),
+	}
+	if err := workflow.SetQueryHandler(ctx, QueryManualSLAState, func() (models.SLAState, error) {
+		return slaState, nil
+	}); err != nil {
+		return err
+	}
+	if err := workflow.SetQueryHandler(ctx, QueryManualCaseState, func() (models.ManualCaseState, error) {
+		return caseState, nil
+	}); err != nil {
+		return err
+	}
+
+	timerCtx, cancelTimers := workflow.WithCancel(ctx)
+	defer cancelTimers()
+
+	addSLATimer := func(level string, after time.Duration) *time.Time {
+		if after <= 0 {
+			return nil
+		}
+		deadline := slaState.EnteredAt.Add(after)
+		selector.AddFuture(workflow.NewTimer(timerCtx, after), func(f workflow.Future) {
+			if f.Get(ctx, nil) == nil {
+				firedLevel = level
+			}
+		})
+		return &deadline
+	}
+	slaState.WarningAt = addSLATimer(models.SLAStatusWarning, policy.WarningAfter)
+	slaState.BreachAt = addSLATimer(models.SLAStatusBreached, policy.BreachAfter)
+	slaState.AutoResolveAt = addSLATimer(models.ResolutionAutoResolved, policy.AutoResolveAfter)
+
+	// Wait for manual resolution signal
 	selector.AddReceive(resolveChannel, func(c workflow.ReceiveChannel, more bool) {
+		// the signal does not say who sent it, so it is not credited to the assignee
 		c.Receive(ctx, &resolveSignal)
+		audit(models.CaseActionResolve, resolvedBy, resolveSignal, false)
 	})
-	selector.Select(ctx)
-
-	if resolveSignal != "" {
-
-		activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-			StartToCloseTimeout: 5 * time.Minute,
-			HeartbeatTimeout:    30 * time.Second,
-			RetryPolicy: &temporal.RetryPolicy{
-				InitialInterval:    time.Second * 5,
-				BackoffCoefficient: 1.5,
-				MaximumInterval:    time.Minute * 2,
-				MaximumAttempts:    3,
-			},
-		})
-		if err := workflow.ExecuteActivity(activityCtx, activities.ConcludeQueryOrder, models.ConcludeQueryOrderRequest{
-			OrderID:            request.OrderID,
-			Resolution:         resolveSignal,
-			OriginalWorkflowID: request.OriginalWorkflowID,
-			ResolvedAt:         workflow.Now(ctx),
-			ResolvedBy:         "manual-intervention",
-		}).Get(ctx, nil); err != nil {
-			logger.Error("Failed to conclude order", "error", err.Error())
-			return err
+	selector.AddReceive(caseChannel, func(c workflow.ReceiveChannel, more bool) {
+		var action models.ManualCaseAction
+		c.Receive(ctx, &action)
+
+		detail, reason := applyCaseAction(&caseState, action, enforceClaims)
+		if reason != "" {
+			logger.Warn("Rejected manual case action", "action", action.Action, "operator", action.Operator, "reason", reason)
+			audit(action.Action, action.Operator, reason, true)
+			return
+		}
+		audit(action.Action, action.Operator, detail, false)
+
+		if action.Action == models.CaseActionResolve {
+			resolveSignal = action.Resolution
+			resolvedBy = action.Operator
 		}
+	})
+
+	for resolveSignal == "" {
+		firedLevel = ""
+		selector.Select(ctx)
 
-		logger.Info("ManualHandle workflow completed successfully",
-			"orderID", request.OrderID,
-			"resolution", resolveSignal)
+		switch firedLevel {
+		case models.SLAStatusWarning, models.SLAStatusBreached:
+			deadline := *slaState.WarningAt
+			if firedLevel == models.SLAStatusBreached {
+				deadline = *slaState.BreachAt
+			}
+			slaState.Status = firedLevel
+			slaState.AlertsSent = append(slaState.AlertsSent, firedLevel)
+			audit(models.CaseActionAlert, "system", firedLevel, false)
+
+			logger.Warn("Manual order SLA threshold reached", "orderID", request.OrderID, "level", firedLevel)
+			if err := workflow.ExecuteActivity(activityCtx, activities.SendSLAAlert, models.SLAAlertRequest{
+				OrderID:      request.OrderID,
+				WorkflowID:   workflow.GetInfo(ctx).WorkflowExecution.ID,
+				BusinessUnit: request.BusinessUnit,
+				Priority:     request.Priority,
+				Level:        firedLevel,
+				EnteredAt:    slaState.EnteredAt,
+				Deadline:     deadline,
+			}).Get(ctx, nil); err != nil {
+				// an undelivered alert must not block the order from being resolved
+				logger.Error("Failed to send SLA alert", "level", firedLevel, "error", err.Error())
+			}
+		case models.ResolutionAutoResolved:
+			resolveSignal = policy.AutoResolution
+			if resolveSignal == "" {
+				resolveSignal = models.ResolutionAutoResolved
+			}
+			resolvedBy = "sla-auto-resolution"
+			audit(models.CaseActionResolve, resolvedBy, resolveSignal, false)
+			logger.Warn("Manual order not resolved before hard timeout - auto-resolving", "orderID", request.OrderID, "resolution", resolveSignal)
+		}
+	}
+
+	cancelTimers()
+	resolvedAt := workflow.Now(ctx)
+	slaState.ResolvedAt = &resolvedAt
+	if slaState.Status == models.SLAStatusWithin {
+		slaState.Status = models.SLAStatusResolved
+	}
+
+	var orderActivity *activities.OrderActivities
+	if err := workflow.ExecuteActivity(activityCtx, orderActivity.ConcludeQueryOrder, models.ConcludeQueryOrderRequest{
+		OrderID:            request.OrderID,
+		Resolution:         resolveSignal,
+		OriginalWorkflowID: request.OriginalWorkflowID,
+		ResolvedAt:         resolvedAt,
+		ResolvedBy:         resolvedBy,
+		AuditTrail:         caseState.AuditTrail,
+		BusinessUnit:       request.BusinessUnit,
+		Priority:           request.Priority,
+		ErrorHistory:       models.NonEmpty(request.OriginalError, request.StaleRetryError),
+		EscalationPath:     []string{models.OrderStageQuery, models.OrderStageStale, models.OrderStageManual},
+	}).Get(ctx, nil); err != nil {
+		logger.Error("Failed to conclude order", "error", err.Error())
+		return err
 	}
 
+	notifyResolution(ctx, models.OrderResolutionEvent{
+		OrderID:      request.OrderID,
+		Resolution:   manualResolution(resolveSignal),
+		Detail:       resolveSignal,
+		ResolvedBy:   resolvedBy,
+		ResolvedAt:   resolvedAt,
+		BusinessUnit: request.BusinessUnit,
+	})
+
+	logger.Info("ManualHandle workflow completed successfully",
+		"orderID", request.OrderID,
+		"resolution", resolveSignal,
+		"resolvedBy", resolvedBy,
+		"slaStatus", slaState.Status)
+
 	return nil
 }
+
+// auditCase appends an entry to the audit trail of a manual case
+func auditCase(ctx workflow.Context, state *models.ManualCaseState, action string, operator string, detail string, rejected bool) {
+	state.AuditTrail = append(state.AuditTrail, models.AuditEntry{
+		Time:     workflow.Now(ctx),
+		Action:   action,
+		Operator: operator,
+		Detail:   detail,
+		Rejected: rejected,
+	})
+}
+
+// applyCaseAction validates an operator action against the case and applies it,
+// returning the audit detail or the reason the action was rejected. With enforceClaims, only the assignee
+// of a claimed case reassigns or resolves it, unless the action is an override
+func applyCaseAction(state *models.ManualCaseState, action models.ManualCaseAction, enforceClaims bool) (detail string, rejected string) {
+	if action.Operator == "" {
+		return "", "operator identity is required"
+	}
+	if enforceClaims && (action.Action == models.CaseActionReassign || action.Action == models.CaseActionResolve) &&
+		state.Assignee != "" && state.Assignee != action.Operator && !action.Override {
+		return "", "case is claimed by " + state.Assignee + "; override to act on it"
+	}
+	override := ""
+	if action.Override && state.Assignee != "" && state.Assignee != action.Operator {
+		override = " (override of " + state.Assignee + ")"
+	}
+
+	switch action.Action {
+	case models.CaseActionClaim:
+		if state.Assignee != "" && state.Assignee != action.Operator {
+			return "", "case already claimed by " + state.Assignee
+		}
+		state.Assignee = action.Operator
+		return action.Comment, ""
+	case models.CaseActionUnclaim:
+		if state.Assignee != action.Operator {
+			return "", "case is not claimed by " + action.Operator
+		}
+		state.Assignee = ""
+		return action.Comment, ""
+	case models.CaseActionReassign:
+		if action.Assignee == "" {
+			return "", "assignee is required"
+		}
+		previous := state.Assignee
+		state.Assignee = action.Assignee
+		return strings.TrimSpace(fmt.Sprintf("from '%s' to '%s' %s", previous, action.Assignee, action.Comment)) + override, ""
+	case models.CaseActionComment:
+		if action.Comment == "" {
+			return "", "comment is required"
+		}
+		return action.Comment, ""
+	case models.CaseActionResolve:
+		if action.Resolution == "" {
+			return "", "resolution is required"
+		}
+		return strings.TrimSpace(action.Resolution+" "+action.Comment) + override, ""
+	default:
+		return "", "unknown action " + action.Action
+	}
+}
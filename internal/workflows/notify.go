@@ -0,0 +1,43 @@
+package workflows
+
+import (
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// notifyResolution sends the terminal resolution of an order to the payment services.
+// Delivery is retried by the activity retry policy; if it still fails the order itself is not failed
+func notifyResolution(ctx workflow.Context, event models.OrderResolutionEvent) {
+	var notifier *activities.Notifier
+
+	info := workflow.GetInfo(ctx)
+	event.EventID = info.WorkflowExecution.ID + "/" + info.WorkflowExecution.RunID
+	event.WorkflowID = info.WorkflowExecution.ID
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 2 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Minute,
+			MaximumAttempts:    10,
+		},
+	})
+	if err := workflow.ExecuteActivity(ctx, notifier.NotifyOrderResolution, event).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to notify order resolution", "orderID", event.OrderID, "resolution", event.Resolution, "error", err.Error())
+	}
+}
+
+// manualResolution maps the resolution an operator sent to a manual workflow to a terminal resolution
+func manualResolution(resolution string) string {
+	switch resolution {
+	case models.ResolutionFailed, models.ResolutionAutoResolved:
+		return models.ResolutionFailed
+	default:
+		return models.ResolutionManualResolve
+	}
+}
@@ -0,0 +1,297 @@
+package workflows
+
+import (
+	"fmt"
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	MaxLifecycleHistoryLength = 10000 // continue-as-new before the history grows past this
+)
+
+// OrderLifecycle handles query, delayed retry and manual resolution of an order in a single execution
+// It is an alternative to the QueryOrder -> Stale -> ManualHandleOrder chain, keyed by order ID,
+// and accepts the same resolve signals as the Stale and ManualHandleOrder workflows. In the manual stage it also
+// takes case actions and serves the case queries of ManualHandleOrder, without SLA timers
+func OrderLifecycle(ctx workflow.Context, request models.OrderLifecycleRequest) (string, error) {
+
+	var (
+		logger                 = workflow.GetLogger(ctx)
+		resolveStaleChannel    = workflow.GetSignalChannel(ctx, SignalResolveStaleWorkflow)
+		resolveManualChannel   = workflow.GetSignalChannel(ctx, SignalResolveManualOrder)
+		caseChannel            = workflow.GetSignalChannel(ctx, SignalManualCaseAction)
+		resolution, resolvedBy string
+		caseActions            bool
+		orderActivity          *activities.OrderActivities
+	)
+
+	if request.Stage == "" {
+		request.Stage = models.OrderStageQuery
+		request.StartedAt = workflow.Now(ctx)
+	}
+
+	for resolution == "" {
+		switch request.Stage {
+		case models.OrderStageQuery:
+			queryCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+				StartToCloseTimeout: 2 * time.Minute,
+				HeartbeatTimeout:    10 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second,
+					BackoffCoefficient: 2.0,
+					MaximumInterval:    time.Minute,
+					MaximumAttempts:    3,
+				},
+			})
+			if err := queryOrder(queryCtx, request.OrderID, memoString(ctx, "businessUnit"), memoString(ctx, "environment")); err != nil {
+				logger.Info("Order query failed after maximum retries - moving to stale stage", "orderID", request.OrderID, "error", err.Error())
+				request.OriginalError = err.Error()
+				if err := setOrderStage(ctx, &request, models.OrderStageStale, 2); err != nil {
+					return "", err
+				}
+				break
+			}
+			resolution, resolvedBy = models.ResolutionSuccess, "query-order"
+
+		case models.OrderStageStale:
+			var (
+				selector      = workflow.NewSelector(ctx)
+				retryTimer    = workflow.NewTimer(ctx, RetryDuration)
+				resolveSignal string
+				timerFired    bool
+			)
+			selector.AddReceive(resolveStaleChannel, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, &resolveSignal)
+				logger.Info("Received resolve signal for stale order", "signal", resolveSignal)
+			})
+			selector.AddFuture(retryTimer, func(f workflow.Future) {
+				timerFired = true
+				logger.Info("Retry timer expired - attempting to retry order query", "orderID", request.OrderID)
+			})
+			selector.Select(ctx)
+
+			if resolveSignal != "" {
+				resolution, resolvedBy = resolveSignal, "manual-intervention"
+				break
+			}
+			if !timerFired {
+				break
+			}
+
+			retryCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+				StartToCloseTimeout: 2 * time.Minute,
+				HeartbeatTimeout:    10 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second * 30,
+					BackoffCoefficient: 2.0,
+					MaximumInterval:    time.Minute * 5,
+					MaximumAttempts:    RetryQueryOrderCount,
+				},
+			})
+			if err := queryOrder(retryCtx, request.OrderID, memoString(ctx, "businessUnit"), memoString(ctx, "environment")); err != nil {
+				logger.Info("Stale order retry failed - moving to manual stage", "orderID", request.OrderID, "error", err.Error())
+				request.StaleRetryError = err.Error()
+				if err := setOrderStage(ctx, &request, models.OrderStageManual, 3); err != nil {
+					return "", err
+				}
+				break
+			}
+			resolution, resolvedBy = models.ResolutionRetrySuccess, "stale-retry"
+
+		case models.OrderStageManual:
+			// orders that reached the manual stage before case actions only take the resolve signal
+			if workflow.GetVersion(ctx, "lifecycle-manual-case", workflow.DefaultVersion, 1) == 1 {
+				caseActions = true
+				var err error
+				if resolution, resolvedBy, err = waitForManualCase(ctx, &request, resolveManualChannel, caseChannel); err != nil {
+					return "", err
+				}
+				break
+			}
+
+			// Wait indefinitely for manual resolution signal
+			var resolveSignal string
+			resolveManualChannel.Receive(ctx, &resolveSignal)
+			if resolveSignal == "" {
+				logger.Warn("Ignoring empty manual resolution signal", "orderID", request.OrderID)
+				break
+			}
+			resolution, resolvedBy = resolveSignal, "manual-intervention"
+
+		default:
+			return "", fmt.Errorf("unknown order stage %q", request.Stage)
+		}
+
+		channels := []workflow.ReceiveChannel{resolveStaleChannel, resolveManualChannel}
+		if caseActions {
+			channels = append(channels, caseChannel)
+		}
+		if resolution == "" && shouldContinueAsNew(ctx, channels...) {
+			request.RunCount++
+			logger.Info("Continuing order lifecycle as new", "orderID", request.OrderID, "stage", request.Stage, "runCount", request.RunCount)
+			return "", workflow.NewContinueAsNewError(ctx, OrderLifecycle, request)
+		}
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 5,
+			BackoffCoefficient: 1.5,
+			MaximumInterval:    time.Minute * 2,
+			MaximumAttempts:    3,
+		},
+	})
+	escalationPath := []string{models.OrderStageQuery}
+	if request.OriginalError != "" {
+		escalationPath = append(escalationPath, models.OrderStageStale)
+	}
+	if request.StaleRetryError != "" {
+		escalationPath = append(escalationPath, models.OrderStageManual)
+	}
+
+	var auditTrail []models.AuditEntry
+	if request.ManualCase != nil {
+		auditTrail = request.ManualCase.AuditTrail
+	}
+
+	if err := workflow.ExecuteActivity(activityCtx, orderActivity.ConcludeQueryOrder, models.ConcludeQueryOrderRequest{
+		OrderID:            request.OrderID,
+		Resolution:         resolution,
+		OriginalWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
+		ResolvedAt:         workflow.Now(ctx),
+		ResolvedBy:         resolvedBy,
+		AuditTrail:         auditTrail,
+		BusinessUnit:       memoString(ctx, "businessUnit"),
+		Priority:           memoString(ctx, "priority"),
+		StartedAt:          request.StartedAt,
+		ErrorHistory:       models.NonEmpty(request.OriginalError, request.StaleRetryError),
+		EscalationPath:     escalationPath,
+	}).Get(ctx, nil); err != nil {
+		logger.Error("Failed to conclude order", "error", err.Error())
+		return "", err
+	}
+
+	event := models.OrderResolutionEvent{
+		OrderID:      request.OrderID,
+		Resolution:   manualResolution(resolution),
+		Detail:       resolution,
+		ResolvedBy:   resolvedBy,
+		ResolvedAt:   workflow.Now(ctx),
+		BusinessUnit: memoString(ctx, "businessUnit"),
+	}
+	if resolvedBy == "query-order" || resolvedBy == "stale-retry" {
+		event.Resolution, event.Detail = resolution, ""
+	}
+	notifyResolution(ctx, event)
+
+	logger.Info("OrderLifecycle workflow completed successfully",
+		"orderID", request.OrderID,
+		"stage", request.Stage,
+		"resolution", resolution)
+
+	return resolution, nil
+}
+
+// waitForManualCase serves the queries of a manual case and waits for one resolve signal or case action, so the
+// caller can continue-as-new between them. The case is carried in the request across runs
+func waitForManualCase(ctx workflow.Context, request *models.OrderLifecycleRequest, resolveChannel workflow.ReceiveChannel, caseChannel workflow.ReceiveChannel) (resolution string, resolvedBy string, err error) {
+	logger := workflow.GetLogger(ctx)
+	if request.ManualCase == nil {
+		request.ManualCase = &models.ManualCaseState{OrderID: request.OrderID}
+		auditCase(ctx, request.ManualCase, models.CaseActionOpen, "system", request.StaleRetryError, false)
+	}
+	caseState := request.ManualCase
+
+	manualRequest := models.ManualHandleRequest{
+		OriginalWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
+		OrderID:            request.OrderID,
+		FailureReason:      "Stale order retry failed",
+		OriginalError:      request.OriginalError,
+		StaleRetryError:    request.StaleRetryError,
+		BusinessUnit:       memoString(ctx, "businessUnit"),
+		Priority:           memoString(ctx, "priority"),
+	}
+	slaState := models.SLAState{
+		OrderID:      request.OrderID,
+		BusinessUnit: manualRequest.BusinessUnit,
+		Priority:     manualRequest.Priority,
+		Status:       models.SLAStatusWithin,
+		EnteredAt:    request.ManualSince,
+	}
+	if err := workflow.SetQueryHandler(ctx, QueryManualRequest, func() (models.ManualHandleRequest, error) {
+		return manualRequest, nil
+	}); err != nil {
+		return "", "", err
+	}
+	if err := workflow.SetQueryHandler(ctx, QueryManualCaseState, func() (models.ManualCaseState, error) {
+		return *caseState, nil
+	}); err != nil {
+		return "", "", err
+	}
+	if err := workflow.SetQueryHandler(ctx, QueryManualSLAState, func() (models.SLAState, error) {
+		return slaState, nil
+	}); err != nil {
+		return "", "", err
+	}
+
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(resolveChannel, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, &resolution)
+		if resolution == "" {
+			logger.Warn("Ignoring empty manual resolution signal", "orderID", request.OrderID)
+			return
+		}
+		resolvedBy = "manual-intervention"
+		auditCase(ctx, caseState, models.CaseActionResolve, resolvedBy, resolution, false)
+	})
+	selector.AddReceive(caseChannel, func(c workflow.ReceiveChannel, more bool) {
+		var action models.ManualCaseAction
+		c.Receive(ctx, &action)
+
+		detail, reason := applyCaseAction(caseState, action, true)
+		if reason != "" {
+			logger.Warn("Rejected manual case action", "action", action.Action, "operator", action.Operator, "reason", reason)
+			auditCase(ctx, caseState, action.Action, action.Operator, reason, true)
+			return
+		}
+		auditCase(ctx, caseState, action.Action, action.Operator, detail, false)
+		if action.Action == models.CaseActionResolve {
+			resolution, resolvedBy = action.Resolution, action.Operator
+		}
+	})
+	selector.Select(ctx)
+	return resolution, resolvedBy, nil
+}
+
+// setOrderStage moves the order to the next stage and mirrors it in the search attributes
+// the same way the Stale and ManualHandleOrder child workflows are tagged
+func setOrderStage(ctx workflow.Context, request *models.OrderLifecycleRequest, stage string, priority int) error {
+	request.Stage = stage
+	if stage == models.OrderStageManual {
+		request.ManualSince = workflow.Now(ctx)
+	}
+	return workflow.UpsertSearchAttributes(ctx, map[string]any{
+		"CustomKeywordField":  stage,
+		"CustomIntField":      priority,
+		"CustomDatetimeField": workflow.Now(ctx),
+	})
+}
+
+// shouldContinueAsNew reports whether the history should be reset; never while signals are still buffered,
+// since they would be lost with the current run
+func shouldContinueAsNew(ctx workflow.Context, channels ...workflow.ReceiveChannel) bool {
+	for _, c := range channels {
+		if c.Len() > 0 {
+			return false
+		}
+	}
+	info := workflow.GetInfo(ctx)
+	return info.GetContinueAsNewSuggested() || info.GetCurrentHistoryLength() > MaxLifecycleHistoryLength
+}
@@ -0,0 +1,185 @@
+package workflows
+
+import (
+	"fmt"
+	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ProcessingControlWorkflowID = "processing-control"
+	MaxControlHistoryLength     = 10000 // continue-as-new before the history grows past this
+	PauseRecheckInterval        = 15 * time.Minute
+	MaxPauseRechecks            = 96 // a day of rechecks before a held order stops waiting and escalates
+)
+
+const ErrTypeProcessingPaused = "ProcessingPaused"
+
+// ProcessingControl keeps the business units and environments whose order processing is paused.
+// Paused order workflows register as waiters and are signalled when their scope resumes;
+// it runs as a single workflow with ID "processing-control" until terminated
+func ProcessingControl(ctx workflow.Context, state models.ProcessingControlState) error {
+	var (
+		logger         = workflow.GetLogger(ctx)
+		controlChannel = workflow.GetSignalChannel(ctx, SignalProcessingControl)
+		waiterChannel  = workflow.GetSignalChannel(ctx, SignalPauseWaiter)
+	)
+
+	if err := workflow.SetQueryHandler(ctx, QueryPausedScopes, func() ([]models.PausedScope, error) {
+		return state.Paused, nil
+	}); err != nil {
+		return err
+	}
+
+	findPaused := func(scope string, value string) int {
+		for i, paused := range state.Paused {
+			if paused.Scope == scope && paused.Value == value {
+				return i
+			}
+		}
+		return -1
+	}
+	resumeWaiter := func(waiter models.PauseWaiter) {
+		// the waiter may have completed or been terminated meanwhile, so the result is not awaited
+		workflow.SignalExternalWorkflow(ctx, waiter.WorkflowID, waiter.RunID, SignalResumeProcessing, nil)
+	}
+
+	applyControl := func(request models.PauseRequest) {
+		i := findPaused(request.Scope, request.Value)
+		switch {
+		case !request.Resume && i < 0:
+			state.Paused = append(state.Paused, models.PausedScope{
+				Scope:    request.Scope,
+				Value:    request.Value,
+				Operator: request.Operator,
+				Reason:   request.Reason,
+				PausedAt: workflow.Now(ctx),
+			})
+			logger.Info("Processing paused", "scope", request.Scope, "value", request.Value, "operator", request.Operator, "reason", request.Reason)
+		case request.Resume && i >= 0:
+			state.Paused = append(state.Paused[:i], state.Paused[i+1:]...)
+
+			var waiting []models.PauseWaiter
+			for _, waiter := range state.Waiters {
+				if waiter.Scope == request.Scope && waiter.Value == request.Value {
+					resumeWaiter(waiter)
+				} else {
+					waiting = append(waiting, waiter)
+				}
+			}
+			logger.Info("Processing resumed", "scope", request.Scope, "value", request.Value, "operator", request.Operator,
+				"resumedWorkflows", len(state.Waiters)-len(waiting))
+			state.Waiters = waiting
+		}
+	}
+	applyWaiter := func(waiter models.PauseWaiter) {
+		i := findPaused(waiter.Scope, waiter.Value)
+		if i < 0 {
+			// resumed before the waiter registered
+			resumeWaiter(waiter)
+			return
+		}
+		state.Waiters = append(state.Waiters, waiter)
+		state.Paused[i].Waiting++
+	}
+
+	for {
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(controlChannel, func(c workflow.ReceiveChannel, more bool) {
+			var request models.PauseRequest
+			c.Receive(ctx, &request)
+			applyControl(request)
+		})
+		selector.AddReceive(waiterChannel, func(c workflow.ReceiveChannel, more bool) {
+			var waiter models.PauseWaiter
+			c.Receive(ctx, &waiter)
+			applyWaiter(waiter)
+		})
+		selector.Select(ctx)
+
+		info := workflow.GetInfo(ctx)
+		if info.GetContinueAsNewSuggested() || info.GetCurrentHistoryLength() > MaxControlHistoryLength {
+			for {
+				var request models.PauseRequest
+				if !controlChannel.ReceiveAsync(&request) {
+					break
+				}
+				applyControl(request)
+			}
+			for {
+				var waiter models.PauseWaiter
+				if !waiterChannel.ReceiveAsync(&waiter) {
+					break
+				}
+				applyWaiter(waiter)
+			}
+			return workflow.NewContinueAsNewError(ctx, ProcessingControl, state)
+		}
+	}
+}
+
+// waitWhilePaused blocks while the business unit or environment of the order is paused. It registers
+// with the processing control workflow to be signalled on resume, and rechecks periodically in case that
+// signal is lost. If the pause state cannot be read the order is not held.
+// When limited it gives up with a ProcessingPaused error after MaxPauseRechecks, or earlier when the server
+// suggests continue-as-new, so a long pause cannot grow the history without bound
+func waitWhilePaused(ctx workflow.Context, businessUnit string, environment string, limited bool) error {
+	var (
+		logger         = workflow.GetLogger(ctx)
+		pauseActivity  *activities.PauseControl
+		resumeChannel  = workflow.GetSignalChannel(ctx, SignalResumeProcessing)
+		localCtx       = workflow.WithLocalActivityOptions(ctx, workflow.LocalActivityOptions{ScheduleToCloseTimeout: 30 * time.Second})
+		pausedSince    time.Time
+		pausedScope    *models.PausedScope
+		registeredWith string
+	)
+
+	for rechecks := 0; ; rechecks++ {
+		pausedScope = nil
+		if err := workflow.ExecuteLocalActivity(localCtx, pauseActivity.PausedScope, businessUnit, environment).Get(ctx, &pausedScope); err != nil {
+			logger.Warn("Unable to read pause state - continuing", "error", err.Error())
+			return nil
+		}
+		if pausedScope == nil {
+			if !pausedSince.IsZero() {
+				logger.Info("Processing resumed", "pausedFor", workflow.Now(ctx).Sub(pausedSince).String())
+			}
+			return nil
+		}
+		if limited && (rechecks >= MaxPauseRechecks || workflow.GetInfo(ctx).GetContinueAsNewSuggested()) {
+			logger.Warn("Processing still paused - no longer holding order", "pausedFor", workflow.Now(ctx).Sub(pausedSince).String())
+			return temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("processing paused for %s %s: %s", pausedScope.Scope, pausedScope.Value, pausedScope.Reason),
+				ErrTypeProcessingPaused, nil)
+		}
+
+		if pausedSince.IsZero() {
+			pausedSince = workflow.Now(ctx)
+			logger.Info("Processing paused - holding order", "scope", pausedScope.Scope, "value", pausedScope.Value, "reason", pausedScope.Reason)
+		}
+		if key := pausedScope.Scope + "/" + pausedScope.Value; key != registeredWith {
+			info := workflow.GetInfo(ctx)
+			_ = workflow.SignalExternalWorkflow(ctx, ProcessingControlWorkflowID, "", SignalPauseWaiter, models.PauseWaiter{
+				WorkflowID: info.WorkflowExecution.ID,
+				RunID:      info.WorkflowExecution.RunID,
+				Scope:      pausedScope.Scope,
+				Value:      pausedScope.Value,
+			}).Get(ctx, nil)
+			registeredWith = key
+		}
+
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(resumeChannel, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, nil)
+			registeredWith = "" // the control workflow dropped the registration when it resumed the scope
+		})
+		selector.AddFuture(workflow.NewTimer(timerCtx, PauseRecheckInterval), func(f workflow.Future) {})
+		selector.Select(ctx)
+		cancelTimer()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
@@ -27,8 +28,8 @@ func QueryOrder(ctx workflow.Context, orderID string) (string, error) {
 	}
 	ctx = workflow.WithActivityOptions(ctx, options)
 
-	var result any
-	if err := workflow.ExecuteActivity(ctx, activities.QueryOrder, orderID).Get(ctx, &result); err != nil {
+	var orderActivity *activities.OrderActivities
+	if err := queryOrder(ctx, orderID, memoString(ctx, "businessUnit"), memoString(ctx, "environment")); err != nil {
 
 		staleRequest := models.StaleWorkflowRequest{
 			OriginalWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
@@ -38,6 +39,9 @@ func QueryOrder(ctx workflow.Context, orderID string) (string, error) {
 			FailureTime:        workflow.Now(ctx),
 			MaxAttemptsReached: retryPolicy.MaximumAttempts,
 			OriginalError:      err.Error(),
+			BusinessUnit:       memoString(ctx, "businessUnit"),
+			Priority:           memoString(ctx, "priority"),
+			Environment:        memoString(ctx, "environment"),
 			Metadata: map[string]any{
 				"workflowType":              "QueryOrderWorkflow",
 				"taskQueue":                 "stale-order",
@@ -69,5 +73,41 @@ func QueryOrder(ctx workflow.Context, orderID string) (string, error) {
 		return "Job moved to Stale Queue", nil
 	}
 
+	if err := workflow.ExecuteActivity(ctx, orderActivity.ConcludeQueryOrder, models.ConcludeQueryOrderRequest{
+		OrderID:            orderID,
+		Resolution:         models.ResolutionSuccess,
+		OriginalWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
+		ResolvedAt:         workflow.Now(ctx),
+		ResolvedBy:         "query-order",
+		BusinessUnit:       memoString(ctx, "businessUnit"),
+		Priority:           memoString(ctx, "priority"),
+		StartedAt:          workflow.GetInfo(ctx).WorkflowStartTime,
+		EscalationPath:     []string{models.OrderStageQuery},
+	}).Get(ctx, nil); err != nil {
+		logger.Error("Failed to conclude order", "error", err.Error())
+		return "", err
+	}
+
+	notifyResolution(ctx, models.OrderResolutionEvent{
+		OrderID:      orderID,
+		Resolution:   models.ResolutionSuccess,
+		ResolvedBy:   "query-order",
+		ResolvedAt:   workflow.Now(ctx),
+		BusinessUnit: memoString(ctx, "businessUnit"),
+	})
+
 	return "Order was queried successfully", nil
 }
+
+// memoString decodes a string value from the workflow memo, returning an empty string if it is not set
+func memoString(ctx workflow.Context, key string) string {
+	var value string
+	memo := workflow.GetInfo(ctx).Memo
+	if memo == nil {
+		return value
+	}
+	if payload, ok := memo.GetFields()[key]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(payload, &value)
+	}
+	return value
+}
@@ -2,13 +2,16 @@ package workflows
 
 import (
 	"temporal-playground/internal/activities"
+	"temporal-playground/internal/models"
 	"time"
 
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
-func RegisterRecurringPayment(ctx workflow.Context, consentID string) (string, error) {
+// RegisterRecurringPayment charges one term of a recurring payment contract. It is started by the contract's
+// schedule, which passes the contract as its argument
+func RegisterRecurringPayment(ctx workflow.Context, contract models.RecurringPaymentContract) (string, error) {
 
 	logger := workflow.GetLogger(ctx)
 	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
@@ -20,22 +23,59 @@ func RegisterRecurringPayment(ctx workflow.Context, consentID string) (string, e
 		},
 	})
 
-	var result string
-	var err error
+	var (
+		result              string
+		err                 error
+		recurringActivities *activities.RecurringPaymentActivities
+		term                = chargeTerm(ctx, contract.ConsentID)
+	)
 
 	// try changing the value of max version here.
-	// always increase the max version param instead of lowering it
-	version := workflow.GetVersion(ctx, "recurring-payment", workflow.DefaultVersion, 3)
+	// always increase the max version param instead of lowering it;
+	// `versions report --change-id recurring-payment` tells which cases are still in use before one is dropped
+	version := workflow.GetVersion(ctx, "recurring-payment", workflow.DefaultVersion, 4)
 	logger.Info("Using recurring payment version", "version", version)
 
+	consentID := contract.ConsentID
 	switch version {
 	case 1:
-		err = workflow.ExecuteActivity(ctx, activities.RecurringPaymentV1, consentID).Get(ctx, &result)
+		err = workflow.ExecuteActivity(ctx, recurringActivities.RecurringPaymentV1, consentID, term).Get(ctx, &result)
 	case 2:
-		err = workflow.ExecuteActivity(ctx, activities.RecurringPaymentV2, consentID).Get(ctx, &result)
+		err = workflow.ExecuteActivity(ctx, recurringActivities.RecurringPaymentV2, consentID, term).Get(ctx, &result)
 	case 3:
-		err = workflow.ExecuteActivity(ctx, activities.RecurringPaymentV3, consentID).Get(ctx, &result)
+		err = workflow.ExecuteActivity(ctx, recurringActivities.RecurringPaymentV3, consentID, term).Get(ctx, &result)
+	case 4:
+		if contract.Amount.Currency == "" {
+			// schedules created before contracts pass only the consent ID, keep charging them the version 3 amount
+			logger.Warn("Recurring payment schedule has no contract - charging the legacy amount", "consentID", consentID)
+			err = workflow.ExecuteActivity(ctx, recurringActivities.RecurringPaymentV3, consentID, term).Get(ctx, &result)
+			break
+		}
+		err = workflow.ExecuteActivity(ctx, recurringActivities.ChargeRecurringPayment, contract, term).Get(ctx, &result)
+
+		// the next term is charged by the schedule, the failed one is retried by dunning
+		if err != nil && workflow.GetVersion(ctx, "recurring-payment-dunning", workflow.DefaultVersion, 1) == 1 {
+			if dunningErr := startDunning(ctx, contract, term, err); dunningErr != nil {
+				logger.Error("Failed to start dunning", "consentID", consentID, "error", dunningErr.Error())
+			} else {
+				logger.Info("Recurring payment failed - dunning started", "consentID", consentID, "error", err.Error())
+			}
+		}
 	}
 
 	return result, err
 }
+
+// chargeTerm identifies the term the payment workflow charges by the time its schedule started it for, which is the
+// same for every attempt of the term. Workflows started outside a schedule use their own start time
+func chargeTerm(ctx workflow.Context, consentID string) models.ChargeTerm {
+	info := workflow.GetInfo(ctx)
+	scheduledAt, ok := workflow.GetTypedSearchAttributes(ctx).GetTime(temporal.NewSearchAttributeKeyTime("TemporalScheduledStartTime"))
+	if !ok {
+		scheduledAt = info.WorkflowStartTime
+	}
+	return models.ChargeTerm{
+		IdempotencyKey: consentID + "/" + scheduledAt.UTC().Format(time.RFC3339),
+		ScheduledAt:    scheduledAt,
+	}
+}
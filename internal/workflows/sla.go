@@ -0,0 +1,34 @@
+package workflows
+
+import (
+	"temporal-playground/internal/models"
+	"time"
+)
+
+// ManualHandleSLAPolicies are the manual queue deadlines per priority level
+var ManualHandleSLAPolicies = map[string]models.SLAPolicy{
+	"urgent": {WarningAfter: 15 * time.Minute, BreachAfter: 1 * time.Hour},
+	"high":   {WarningAfter: 2 * time.Hour, BreachAfter: 4 * time.Hour},
+	"normal": {WarningAfter: 12 * time.Hour, BreachAfter: 24 * time.Hour, AutoResolveAfter: 14 * 24 * time.Hour, AutoResolution: models.ResolutionFailed},
+	"low":    {WarningAfter: 24 * time.Hour, BreachAfter: 72 * time.Hour, AutoResolveAfter: 14 * 24 * time.Hour, AutoResolution: models.ResolutionFailed},
+}
+
+// BusinessUnitSLAPolicies override ManualHandleSLAPolicies for a business unit, keyed by business unit then priority
+var BusinessUnitSLAPolicies = map[string]map[string]models.SLAPolicy{
+	"enterprise": {
+		"normal": {WarningAfter: 4 * time.Hour, BreachAfter: 8 * time.Hour},
+	},
+}
+
+// LookupSLAPolicy returns the SLA policy for a business unit and priority, falling back to the normal priority
+func LookupSLAPolicy(businessUnit string, priority string) models.SLAPolicy {
+	if policies, ok := BusinessUnitSLAPolicies[businessUnit]; ok {
+		if policy, ok := policies[priority]; ok {
+			return policy
+		}
+	}
+	if policy, ok := ManualHandleSLAPolicies[priority]; ok {
+		return policy
+	}
+	return ManualHandleSLAPolicies["normal"]
+}
@@ -36,9 +36,12 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 		logger         = workflow.GetLogger(ctx)
 		retryTimer     = workflow.NewTimer(ctx, RetryDuration)
 		selector       = workflow.NewSelector(ctx)
-		resolveChannel = workflow.GetSignalChannel(ctx, "resolve-stale-workflow")
+		resolveChannel = workflow.GetSignalChannel(ctx, SignalResolveStaleWorkflow)
 		resolveSignal  string
+		resolvedBy     string
+		staleRetryErr  string
 		timerFired     bool
+		orderActivity  *activities.OrderActivities
 	)
 
 	selector.AddReceive(resolveChannel, func(c workflow.ReceiveChannel, more bool) {
@@ -54,6 +57,7 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 
 	// Handle the result
 	if resolveSignal != "" {
+		resolvedBy = "manual-intervention"
 		logger.Info("Manually resolved stale workflow", "resolution", resolveSignal)
 	} else if timerFired {
 
@@ -68,8 +72,8 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 			},
 		})
 
-		var retryResult any
-		if err := workflow.ExecuteActivity(retryCtx, activities.QueryOrder, request.OrderID).Get(ctx, &retryResult); err != nil {
+		if err := queryOrder(retryCtx, request.OrderID, request.BusinessUnit, request.Environment); err != nil {
+			staleRetryErr = err.Error()
 
 			manualRequest := models.ManualHandleRequest{
 				OriginalWorkflowID: request.OriginalWorkflowID,
@@ -79,6 +83,8 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 				StaleFailureTime:   workflow.Now(ctx),
 				OriginalError:      request.OriginalError,
 				StaleRetryError:    err.Error(),
+				BusinessUnit:       request.BusinessUnit,
+				Priority:           request.Priority,
 				Metadata: map[string]any{
 					"workflowType":           "StaleWorkflow",
 					"staleWorkflowStartTime": request.FailureTime,
@@ -104,7 +110,8 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 
 			resolveSignal = "moved-to-manual-handle"
 		} else {
-			resolveSignal = "retry-succeeded"
+			resolveSignal = models.ResolutionRetrySuccess
+			resolvedBy = "stale-retry"
 		}
 	}
 
@@ -112,15 +119,38 @@ func Stale(ctx workflow.Context, request models.StaleWorkflowRequest) error {
 	finalRequest := request
 	finalRequest.Metadata["resolvedAt"] = time.Now()
 	finalRequest.Metadata["resolution"] = resolveSignal
+	finalRequest.Metadata["resolvedBy"] = resolvedBy
+	finalRequest.Metadata["staleRetryError"] = staleRetryErr
 	if resolveSignal == "" {
 		finalRequest.Metadata["resolution"] = "unknown"
 	}
 
 	var finalResult any
-	if err := workflow.ExecuteActivity(ctx, activities.FinalizeStaleWorkflow, finalRequest).Get(ctx, &finalResult); err != nil {
+	if err := workflow.ExecuteActivity(ctx, orderActivity.FinalizeStaleWorkflow, finalRequest).Get(ctx, &finalResult); err != nil {
 		logger.Error("Failed to finalize stale workflow", "error", err.Error())
 		return err
 	}
 
+	// Orders moved to the manual queue are notified once the manual workflow resolves them
+	switch {
+	case resolveSignal == models.ResolutionRetrySuccess:
+		notifyResolution(ctx, models.OrderResolutionEvent{
+			OrderID:      request.OrderID,
+			Resolution:   models.ResolutionRetrySuccess,
+			ResolvedBy:   "stale-retry",
+			ResolvedAt:   workflow.Now(ctx),
+			BusinessUnit: request.BusinessUnit,
+		})
+	case resolveSignal != "" && !timerFired:
+		notifyResolution(ctx, models.OrderResolutionEvent{
+			OrderID:      request.OrderID,
+			Resolution:   manualResolution(resolveSignal),
+			Detail:       resolveSignal,
+			ResolvedBy:   "manual-intervention",
+			ResolvedAt:   workflow.Now(ctx),
+			BusinessUnit: request.BusinessUnit,
+		})
+	}
+
 	return nil
 }
@@ -0,0 +1,1052 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.29.3
+// source: proto/orchestrator/v1/orchestrator.proto
+
+package orchestratorv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitOrderRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	OrderId      string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Environment  string                 `protobuf:"bytes,2,opt,name=environment,proto3" json:"environment,omitempty"`
+	BusinessUnit string                 `protobuf:"bytes,3,opt,name=business_unit,json=businessUnit,proto3" json:"business_unit,omitempty"`
+	// low, normal, high or urgent
+	Priority string `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// use the single OrderLifecycle workflow instead of QueryOrder
+	Lifecycle     bool `protobuf:"varint,5,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitOrderRequest) Reset() {
+	*x = SubmitOrderRequest{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitOrderRequest) ProtoMessage() {}
+
+func (x *SubmitOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitOrderRequest.ProtoReflect.Descriptor instead.
+func (*SubmitOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubmitOrderRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *SubmitOrderRequest) GetBusinessUnit() string {
+	if x != nil {
+		return x.BusinessUnit
+	}
+	return ""
+}
+
+func (x *SubmitOrderRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *SubmitOrderRequest) GetLifecycle() bool {
+	if x != nil {
+		return x.Lifecycle
+	}
+	return false
+}
+
+type SubmitOrderResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	OrderId    string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	WorkflowId string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId      string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// true when the order was already submitted
+	Duplicate     bool `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitOrderResponse) Reset() {
+	*x = SubmitOrderResponse{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitOrderResponse) ProtoMessage() {}
+
+func (x *SubmitOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitOrderResponse.ProtoReflect.Descriptor instead.
+func (*SubmitOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubmitOrderResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitOrderResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *SubmitOrderResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type GetOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusRequest) Reset() {
+	*x = GetOrderStatusRequest{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusRequest) ProtoMessage() {}
+
+func (x *GetOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type OrderWorkflow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	WorkflowType  string                 `protobuf:"bytes,3,opt,name=workflow_type,json=workflowType,proto3" json:"workflow_type,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Stage         string                 `protobuf:"bytes,5,opt,name=stage,proto3" json:"stage,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	CloseTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderWorkflow) Reset() {
+	*x = OrderWorkflow{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderWorkflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderWorkflow) ProtoMessage() {}
+
+func (x *OrderWorkflow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderWorkflow.ProtoReflect.Descriptor instead.
+func (*OrderWorkflow) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderWorkflow) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *OrderWorkflow) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *OrderWorkflow) GetWorkflowType() string {
+	if x != nil {
+		return x.WorkflowType
+	}
+	return ""
+}
+
+func (x *OrderWorkflow) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderWorkflow) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *OrderWorkflow) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *OrderWorkflow) GetCloseTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CloseTime
+	}
+	return nil
+}
+
+type GetOrderStatusResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// in escalation order: query, stale, manual
+	Workflows     []*OrderWorkflow `protobuf:"bytes,2,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusResponse) Reset() {
+	*x = GetOrderStatusResponse{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusResponse) ProtoMessage() {}
+
+func (x *GetOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrderStatusResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderStatusResponse) GetWorkflows() []*OrderWorkflow {
+	if x != nil {
+		return x.Workflows
+	}
+	return nil
+}
+
+type ResolveManualRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	OrderId    string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Resolution string                 `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	// recorded in the audit trail of the manual workflow
+	Operator      string `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Comment       string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveManualRequest) Reset() {
+	*x = ResolveManualRequest{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveManualRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveManualRequest) ProtoMessage() {}
+
+func (x *ResolveManualRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveManualRequest.ProtoReflect.Descriptor instead.
+func (*ResolveManualRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveManualRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ResolveManualRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *ResolveManualRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ResolveManualRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ResolveManualResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveManualResponse) Reset() {
+	*x = ResolveManualResponse{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveManualResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveManualResponse) ProtoMessage() {}
+
+func (x *ResolveManualResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveManualResponse.ProtoReflect.Descriptor instead.
+func (*ResolveManualResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResolveManualResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ResolveManualResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *ResolveManualResponse) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+type CreateRecurringPaymentRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	// 0 means infinite
+	Terms int32 `protobuf:"varint,2,opt,name=terms,proto3" json:"terms,omitempty"`
+	// Go duration, e.g. 720h; exactly one of interval, cron, days_of_month, weekdays or yearly_dates is required
+	Interval string `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// defaults to Asia/Kuala_Lumpur
+	TimeZone     string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Environment  string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	BusinessUnit string `protobuf:"bytes,6,opt,name=business_unit,json=businessUnit,proto3" json:"business_unit,omitempty"`
+	Priority     string `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	CustomerId   string `protobuf:"bytes,8,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// amount of each payment in the minor units of the currency, e.g. 1550 for MYR 15.50
+	AmountMinor int64 `protobuf:"varint,9,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	// ISO 4217 code
+	Currency string            `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// standard 5 field cron expression, e.g. "0 9 1 * *"
+	Cron string `protobuf:"bytes,12,opt,name=cron,proto3" json:"cron,omitempty"`
+	// e.g. "1,15" or "last"; days past the end of a month fall on its last day
+	DaysOfMonth string `protobuf:"bytes,13,opt,name=days_of_month,json=daysOfMonth,proto3" json:"days_of_month,omitempty"`
+	// e.g. "mon,thu"
+	Weekdays string `protobuf:"bytes,14,opt,name=weekdays,proto3" json:"weekdays,omitempty"`
+	// MM-DD
+	YearlyDates []string `protobuf:"bytes,15,rep,name=yearly_dates,json=yearlyDates,proto3" json:"yearly_dates,omitempty"`
+	// HH:MM of calendar payments, defaults to 00:00
+	TimeOfDay string `protobuf:"bytes,16,opt,name=time_of_day,json=timeOfDay,proto3" json:"time_of_day,omitempty"`
+	// no payments before this date, YYYY-MM-DD or RFC3339
+	Start string `protobuf:"bytes,17,opt,name=start,proto3" json:"start,omitempty"`
+	// no payments after this date; YYYY-MM-DD still takes the payments due that day
+	End string `protobuf:"bytes,18,opt,name=end,proto3" json:"end,omitempty"`
+	// Go duration; each payment is delayed by a random duration up to this
+	Jitter string `protobuf:"bytes,19,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	// YYYY-MM-DD, e.g. public holidays
+	SkipDates []string `protobuf:"bytes,20,rep,name=skip_dates,json=skipDates,proto3" json:"skip_dates,omitempty"`
+	// retries of a failed payment after the failure, e.g. "1d,3d,7d" (the default)
+	Dunning string `protobuf:"bytes,21,opt,name=dunning,proto3" json:"dunning,omitempty"`
+	// skip, buffer-one (the default), buffer-all, cancel-other, terminate-other or allow-all
+	Overlap string `protobuf:"bytes,22,opt,name=overlap,proto3" json:"overlap,omitempty"`
+	// Go duration, defaults to 24h
+	CatchupWindow  string `protobuf:"bytes,23,opt,name=catchup_window,json=catchupWindow,proto3" json:"catchup_window,omitempty"`
+	PauseOnFailure bool   `protobuf:"varint,24,opt,name=pause_on_failure,json=pauseOnFailure,proto3" json:"pause_on_failure,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateRecurringPaymentRequest) Reset() {
+	*x = CreateRecurringPaymentRequest{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecurringPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecurringPaymentRequest) ProtoMessage() {}
+
+func (x *CreateRecurringPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecurringPaymentRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecurringPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateRecurringPaymentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetTerms() int32 {
+	if x != nil {
+		return x.Terms
+	}
+	return 0
+}
+
+func (x *CreateRecurringPaymentRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetBusinessUnit() string {
+	if x != nil {
+		return x.BusinessUnit
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *CreateRecurringPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateRecurringPaymentRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetDaysOfMonth() string {
+	if x != nil {
+		return x.DaysOfMonth
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetWeekdays() string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetYearlyDates() []string {
+	if x != nil {
+		return x.YearlyDates
+	}
+	return nil
+}
+
+func (x *CreateRecurringPaymentRequest) GetTimeOfDay() string {
+	if x != nil {
+		return x.TimeOfDay
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetJitter() string {
+	if x != nil {
+		return x.Jitter
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetSkipDates() []string {
+	if x != nil {
+		return x.SkipDates
+	}
+	return nil
+}
+
+func (x *CreateRecurringPaymentRequest) GetDunning() string {
+	if x != nil {
+		return x.Dunning
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetOverlap() string {
+	if x != nil {
+		return x.Overlap
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetCatchupWindow() string {
+	if x != nil {
+		return x.CatchupWindow
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentRequest) GetPauseOnFailure() bool {
+	if x != nil {
+		return x.PauseOnFailure
+	}
+	return false
+}
+
+type CreateRecurringPaymentResponse struct {
+	state            protoimpl.MessageState   `protogen:"open.v1"`
+	ConsentId        string                   `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	LimitedTerms     bool                     `protobuf:"varint,2,opt,name=limited_terms,json=limitedTerms,proto3" json:"limited_terms,omitempty"`
+	RemainingTerms   int32                    `protobuf:"varint,3,opt,name=remaining_terms,json=remainingTerms,proto3" json:"remaining_terms,omitempty"`
+	NextPaymentTimes []*timestamppb.Timestamp `protobuf:"bytes,4,rep,name=next_payment_times,json=nextPaymentTimes,proto3" json:"next_payment_times,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateRecurringPaymentResponse) Reset() {
+	*x = CreateRecurringPaymentResponse{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecurringPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecurringPaymentResponse) ProtoMessage() {}
+
+func (x *CreateRecurringPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecurringPaymentResponse.ProtoReflect.Descriptor instead.
+func (*CreateRecurringPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateRecurringPaymentResponse) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *CreateRecurringPaymentResponse) GetLimitedTerms() bool {
+	if x != nil {
+		return x.LimitedTerms
+	}
+	return false
+}
+
+func (x *CreateRecurringPaymentResponse) GetRemainingTerms() int32 {
+	if x != nil {
+		return x.RemainingTerms
+	}
+	return 0
+}
+
+func (x *CreateRecurringPaymentResponse) GetNextPaymentTimes() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.NextPaymentTimes
+	}
+	return nil
+}
+
+type CancelRecurringPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId     string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRecurringPaymentRequest) Reset() {
+	*x = CancelRecurringPaymentRequest{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRecurringPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRecurringPaymentRequest) ProtoMessage() {}
+
+func (x *CancelRecurringPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRecurringPaymentRequest.ProtoReflect.Descriptor instead.
+func (*CancelRecurringPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelRecurringPaymentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+type CancelRecurringPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRecurringPaymentResponse) Reset() {
+	*x = CancelRecurringPaymentResponse{}
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRecurringPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRecurringPaymentResponse) ProtoMessage() {}
+
+func (x *CancelRecurringPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_v1_orchestrator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRecurringPaymentResponse.ProtoReflect.Descriptor instead.
+func (*CancelRecurringPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{10}
+}
+
+var File_proto_orchestrator_v1_orchestrator_proto protoreflect.FileDescriptor
+
+const file_proto_orchestrator_v1_orchestrator_proto_rawDesc = "" +
+	"\n" +
+	"(proto/orchestrator/v1/orchestrator.proto\x12\x0forchestrator.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x01\n" +
+	"\x12SubmitOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12 \n" +
+	"\venvironment\x18\x02 \x01(\tR\venvironment\x12#\n" +
+	"\rbusiness_unit\x18\x03 \x01(\tR\fbusinessUnit\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\tR\bpriority\x12\x1c\n" +
+	"\tlifecycle\x18\x05 \x01(\bR\tlifecycle\"\x86\x01\n" +
+	"\x13SubmitOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\"2\n" +
+	"\x15GetOrderStatusRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x90\x02\n" +
+	"\rOrderWorkflow\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12#\n" +
+	"\rworkflow_type\x18\x03 \x01(\tR\fworkflowType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05stage\x18\x05 \x01(\tR\x05stage\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x129\n" +
+	"\n" +
+	"close_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcloseTime\"q\n" +
+	"\x16GetOrderStatusResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12<\n" +
+	"\tworkflows\x18\x02 \x03(\v2\x1e.orchestrator.v1.OrderWorkflowR\tworkflows\"\x87\x01\n" +
+	"\x14ResolveManualRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\tR\n" +
+	"resolution\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"s\n" +
+	"\x15ResolveManualResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\"\xe2\x06\n" +
+	"\x1dCreateRecurringPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12\x14\n" +
+	"\x05terms\x18\x02 \x01(\x05R\x05terms\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironment\x12#\n" +
+	"\rbusiness_unit\x18\x06 \x01(\tR\fbusinessUnit\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\x1f\n" +
+	"\vcustomer_id\x18\b \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\famount_minor\x18\t \x01(\x03R\vamountMinor\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12X\n" +
+	"\bmetadata\x18\v \x03(\v2<.orchestrator.v1.CreateRecurringPaymentRequest.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04cron\x18\f \x01(\tR\x04cron\x12\"\n" +
+	"\rdays_of_month\x18\r \x01(\tR\vdaysOfMonth\x12\x1a\n" +
+	"\bweekdays\x18\x0e \x01(\tR\bweekdays\x12!\n" +
+	"\fyearly_dates\x18\x0f \x03(\tR\vyearlyDates\x12\x1e\n" +
+	"\vtime_of_day\x18\x10 \x01(\tR\ttimeOfDay\x12\x14\n" +
+	"\x05start\x18\x11 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x12 \x01(\tR\x03end\x12\x16\n" +
+	"\x06jitter\x18\x13 \x01(\tR\x06jitter\x12\x1d\n" +
+	"\n" +
+	"skip_dates\x18\x14 \x03(\tR\tskipDates\x12\x18\n" +
+	"\adunning\x18\x15 \x01(\tR\adunning\x12\x18\n" +
+	"\aoverlap\x18\x16 \x01(\tR\aoverlap\x12%\n" +
+	"\x0ecatchup_window\x18\x17 \x01(\tR\rcatchupWindow\x12(\n" +
+	"\x10pause_on_failure\x18\x18 \x01(\bR\x0epauseOnFailure\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x01\n" +
+	"\x1eCreateRecurringPaymentResponse\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12#\n" +
+	"\rlimited_terms\x18\x02 \x01(\bR\flimitedTerms\x12'\n" +
+	"\x0fremaining_terms\x18\x03 \x01(\x05R\x0eremainingTerms\x12H\n" +
+	"\x12next_payment_times\x18\x04 \x03(\v2\x1a.google.protobuf.TimestampR\x10nextPaymentTimes\">\n" +
+	"\x1dCancelRecurringPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\" \n" +
+	"\x1eCancelRecurringPaymentResponse2\xa6\x04\n" +
+	"\x11OrderOrchestrator\x12X\n" +
+	"\vSubmitOrder\x12#.orchestrator.v1.SubmitOrderRequest\x1a$.orchestrator.v1.SubmitOrderResponse\x12a\n" +
+	"\x0eGetOrderStatus\x12&.orchestrator.v1.GetOrderStatusRequest\x1a'.orchestrator.v1.GetOrderStatusResponse\x12^\n" +
+	"\rResolveManual\x12%.orchestrator.v1.ResolveManualRequest\x1a&.orchestrator.v1.ResolveManualResponse\x12y\n" +
+	"\x16CreateRecurringPayment\x12..orchestrator.v1.CreateRecurringPaymentRequest\x1a/.orchestrator.v1.CreateRecurringPaymentResponse\x12y\n" +
+	"\x16CancelRecurringPayment\x12..orchestrator.v1.CancelRecurringPaymentRequest\x1a/.orchestrator.v1.CancelRecurringPaymentResponseB:Z8temporal-playground/proto/orchestrator/v1;orchestratorv1b\x06proto3"
+
+var (
+	file_proto_orchestrator_v1_orchestrator_proto_rawDescOnce sync.Once
+	file_proto_orchestrator_v1_orchestrator_proto_rawDescData []byte
+)
+
+func file_proto_orchestrator_v1_orchestrator_proto_rawDescGZIP() []byte {
+	file_proto_orchestrator_v1_orchestrator_proto_rawDescOnce.Do(func() {
+		file_proto_orchestrator_v1_orchestrator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_orchestrator_v1_orchestrator_proto_rawDesc), len(file_proto_orchestrator_v1_orchestrator_proto_rawDesc)))
+	})
+	return file_proto_orchestrator_v1_orchestrator_proto_rawDescData
+}
+
+var file_proto_orchestrator_v1_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_orchestrator_v1_orchestrator_proto_goTypes = []any{
+	(*SubmitOrderRequest)(nil),             // 0: orchestrator.v1.SubmitOrderRequest
+	(*SubmitOrderResponse)(nil),            // 1: orchestrator.v1.SubmitOrderResponse
+	(*GetOrderStatusRequest)(nil),          // 2: orchestrator.v1.GetOrderStatusRequest
+	(*OrderWorkflow)(nil),                  // 3: orchestrator.v1.OrderWorkflow
+	(*GetOrderStatusResponse)(nil),         // 4: orchestrator.v1.GetOrderStatusResponse
+	(*ResolveManualRequest)(nil),           // 5: orchestrator.v1.ResolveManualRequest
+	(*ResolveManualResponse)(nil),          // 6: orchestrator.v1.ResolveManualResponse
+	(*CreateRecurringPaymentRequest)(nil),  // 7: orchestrator.v1.CreateRecurringPaymentRequest
+	(*CreateRecurringPaymentResponse)(nil), // 8: orchestrator.v1.CreateRecurringPaymentResponse
+	(*CancelRecurringPaymentRequest)(nil),  // 9: orchestrator.v1.CancelRecurringPaymentRequest
+	(*CancelRecurringPaymentResponse)(nil), // 10: orchestrator.v1.CancelRecurringPaymentResponse
+	nil,                                    // 11: orchestrator.v1.CreateRecurringPaymentRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),          // 12: google.protobuf.Timestamp
+}
+var file_proto_orchestrator_v1_orchestrator_proto_depIdxs = []int32{
+	12, // 0: orchestrator.v1.OrderWorkflow.start_time:type_name -> google.protobuf.Timestamp
+	12, // 1: orchestrator.v1.OrderWorkflow.close_time:type_name -> google.protobuf.Timestamp
+	3,  // 2: orchestrator.v1.GetOrderStatusResponse.workflows:type_name -> orchestrator.v1.OrderWorkflow
+	11, // 3: orchestrator.v1.CreateRecurringPaymentRequest.metadata:type_name -> orchestrator.v1.CreateRecurringPaymentRequest.MetadataEntry
+	12, // 4: orchestrator.v1.CreateRecurringPaymentResponse.next_payment_times:type_name -> google.protobuf.Timestamp
+	0,  // 5: orchestrator.v1.OrderOrchestrator.SubmitOrder:input_type -> orchestrator.v1.SubmitOrderRequest
+	2,  // 6: orchestrator.v1.OrderOrchestrator.GetOrderStatus:input_type -> orchestrator.v1.GetOrderStatusRequest
+	5,  // 7: orchestrator.v1.OrderOrchestrator.ResolveManual:input_type -> orchestrator.v1.ResolveManualRequest
+	7,  // 8: orchestrator.v1.OrderOrchestrator.CreateRecurringPayment:input_type -> orchestrator.v1.CreateRecurringPaymentRequest
+	9,  // 9: orchestrator.v1.OrderOrchestrator.CancelRecurringPayment:input_type -> orchestrator.v1.CancelRecurringPaymentRequest
+	1,  // 10: orchestrator.v1.OrderOrchestrator.SubmitOrder:output_type -> orchestrator.v1.SubmitOrderResponse
+	4,  // 11: orchestrator.v1.OrderOrchestrator.GetOrderStatus:output_type -> orchestrator.v1.GetOrderStatusResponse
+	6,  // 12: orchestrator.v1.OrderOrchestrator.ResolveManual:output_type -> orchestrator.v1.ResolveManualResponse
+	8,  // 13: orchestrator.v1.OrderOrchestrator.CreateRecurringPayment:output_type -> orchestrator.v1.CreateRecurringPaymentResponse
+	10, // 14: orchestrator.v1.OrderOrchestrator.CancelRecurringPayment:output_type -> orchestrator.v1.CancelRecurringPaymentResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_orchestrator_v1_orchestrator_proto_init() }
+func file_proto_orchestrator_v1_orchestrator_proto_init() {
+	if File_proto_orchestrator_v1_orchestrator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_orchestrator_v1_orchestrator_proto_rawDesc), len(file_proto_orchestrator_v1_orchestrator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_orchestrator_v1_orchestrator_proto_goTypes,
+		DependencyIndexes: file_proto_orchestrator_v1_orchestrator_proto_depIdxs,
+		MessageInfos:      file_proto_orchestrator_v1_orchestrator_proto_msgTypes,
+	}.Build()
+	File_proto_orchestrator_v1_orchestrator_proto = out.File
+	file_proto_orchestrator_v1_orchestrator_proto_goTypes = nil
+	file_proto_orchestrator_v1_orchestrator_proto_depIdxs = nil
+}
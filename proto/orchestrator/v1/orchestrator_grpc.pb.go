@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/orchestrator/v1/orchestrator.proto
+
+package orchestratorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrderOrchestrator_SubmitOrder_FullMethodName            = "/orchestrator.v1.OrderOrchestrator/SubmitOrder"
+	OrderOrchestrator_GetOrderStatus_FullMethodName         = "/orchestrator.v1.OrderOrchestrator/GetOrderStatus"
+	OrderOrchestrator_ResolveManual_FullMethodName          = "/orchestrator.v1.OrderOrchestrator/ResolveManual"
+	OrderOrchestrator_CreateRecurringPayment_FullMethodName = "/orchestrator.v1.OrderOrchestrator/CreateRecurringPayment"
+	OrderOrchestrator_CancelRecurringPayment_FullMethodName = "/orchestrator.v1.OrderOrchestrator/CancelRecurringPayment"
+)
+
+// OrderOrchestratorClient is the client API for OrderOrchestrator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrderOrchestrator submits, inspects and resolves payment orders and recurring payments
+// without callers having to depend on the Temporal SDK.
+type OrderOrchestratorClient interface {
+	// SubmitOrder starts processing an order. Submitting the same order again is idempotent.
+	SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error)
+	// GetOrderStatus returns the workflows an order has moved through so far.
+	GetOrderStatus(ctx context.Context, in *GetOrderStatusRequest, opts ...grpc.CallOption) (*GetOrderStatusResponse, error)
+	// ResolveManual resolves an order waiting for manual intervention.
+	ResolveManual(ctx context.Context, in *ResolveManualRequest, opts ...grpc.CallOption) (*ResolveManualResponse, error)
+	// CreateRecurringPayment schedules a recurring payment for a consent.
+	CreateRecurringPayment(ctx context.Context, in *CreateRecurringPaymentRequest, opts ...grpc.CallOption) (*CreateRecurringPaymentResponse, error)
+	// CancelRecurringPayment deletes the recurring payment schedule of a consent.
+	CancelRecurringPayment(ctx context.Context, in *CancelRecurringPaymentRequest, opts ...grpc.CallOption) (*CancelRecurringPaymentResponse, error)
+}
+
+type orderOrchestratorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderOrchestratorClient(cc grpc.ClientConnInterface) OrderOrchestratorClient {
+	return &orderOrchestratorClient{cc}
+}
+
+func (c *orderOrchestratorClient) SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitOrderResponse)
+	err := c.cc.Invoke(ctx, OrderOrchestrator_SubmitOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderOrchestratorClient) GetOrderStatus(ctx context.Context, in *GetOrderStatusRequest, opts ...grpc.CallOption) (*GetOrderStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderStatusResponse)
+	err := c.cc.Invoke(ctx, OrderOrchestrator_GetOrderStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderOrchestratorClient) ResolveManual(ctx context.Context, in *ResolveManualRequest, opts ...grpc.CallOption) (*ResolveManualResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveManualResponse)
+	err := c.cc.Invoke(ctx, OrderOrchestrator_ResolveManual_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderOrchestratorClient) CreateRecurringPayment(ctx context.Context, in *CreateRecurringPaymentRequest, opts ...grpc.CallOption) (*CreateRecurringPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRecurringPaymentResponse)
+	err := c.cc.Invoke(ctx, OrderOrchestrator_CreateRecurringPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderOrchestratorClient) CancelRecurringPayment(ctx context.Context, in *CancelRecurringPaymentRequest, opts ...grpc.CallOption) (*CancelRecurringPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelRecurringPaymentResponse)
+	err := c.cc.Invoke(ctx, OrderOrchestrator_CancelRecurringPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderOrchestratorServer is the server API for OrderOrchestrator service.
+// All implementations must embed UnimplementedOrderOrchestratorServer
+// for forward compatibility.
+//
+// OrderOrchestrator submits, inspects and resolves payment orders and recurring payments
+// without callers having to depend on the Temporal SDK.
+type OrderOrchestratorServer interface {
+	// SubmitOrder starts processing an order. Submitting the same order again is idempotent.
+	SubmitOrder(context.Context, *SubmitOrderRequest) (*SubmitOrderResponse, error)
+	// GetOrderStatus returns the workflows an order has moved through so far.
+	GetOrderStatus(context.Context, *GetOrderStatusRequest) (*GetOrderStatusResponse, error)
+	// ResolveManual resolves an order waiting for manual intervention.
+	ResolveManual(context.Context, *ResolveManualRequest) (*ResolveManualResponse, error)
+	// CreateRecurringPayment schedules a recurring payment for a consent.
+	CreateRecurringPayment(context.Context, *CreateRecurringPaymentRequest) (*CreateRecurringPaymentResponse, error)
+	// CancelRecurringPayment deletes the recurring payment schedule of a consent.
+	CancelRecurringPayment(context.Context, *CancelRecurringPaymentRequest) (*CancelRecurringPaymentResponse, error)
+	mustEmbedUnimplementedOrderOrchestratorServer()
+}
+
+// UnimplementedOrderOrchestratorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrderOrchestratorServer struct{}
+
+func (UnimplementedOrderOrchestratorServer) SubmitOrder(context.Context, *SubmitOrderRequest) (*SubmitOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrder not implemented")
+}
+func (UnimplementedOrderOrchestratorServer) GetOrderStatus(context.Context, *GetOrderStatusRequest) (*GetOrderStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderStatus not implemented")
+}
+func (UnimplementedOrderOrchestratorServer) ResolveManual(context.Context, *ResolveManualRequest) (*ResolveManualResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveManual not implemented")
+}
+func (UnimplementedOrderOrchestratorServer) CreateRecurringPayment(context.Context, *CreateRecurringPaymentRequest) (*CreateRecurringPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecurringPayment not implemented")
+}
+func (UnimplementedOrderOrchestratorServer) CancelRecurringPayment(context.Context, *CancelRecurringPaymentRequest) (*CancelRecurringPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRecurringPayment not implemented")
+}
+func (UnimplementedOrderOrchestratorServer) mustEmbedUnimplementedOrderOrchestratorServer() {}
+func (UnimplementedOrderOrchestratorServer) testEmbeddedByValue()                           {}
+
+// UnsafeOrderOrchestratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderOrchestratorServer will
+// result in compilation errors.
+type UnsafeOrderOrchestratorServer interface {
+	mustEmbedUnimplementedOrderOrchestratorServer()
+}
+
+func RegisterOrderOrchestratorServer(s grpc.ServiceRegistrar, srv OrderOrchestratorServer) {
+	// If the following call pancis, it indicates UnimplementedOrderOrchestratorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrderOrchestrator_ServiceDesc, srv)
+}
+
+func _OrderOrchestrator_SubmitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderOrchestratorServer).SubmitOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderOrchestrator_SubmitOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderOrchestratorServer).SubmitOrder(ctx, req.(*SubmitOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderOrchestrator_GetOrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderOrchestratorServer).GetOrderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderOrchestrator_GetOrderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderOrchestratorServer).GetOrderStatus(ctx, req.(*GetOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderOrchestrator_ResolveManual_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveManualRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderOrchestratorServer).ResolveManual(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderOrchestrator_ResolveManual_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderOrchestratorServer).ResolveManual(ctx, req.(*ResolveManualRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderOrchestrator_CreateRecurringPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecurringPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderOrchestratorServer).CreateRecurringPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderOrchestrator_CreateRecurringPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderOrchestratorServer).CreateRecurringPayment(ctx, req.(*CreateRecurringPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderOrchestrator_CancelRecurringPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRecurringPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderOrchestratorServer).CancelRecurringPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderOrchestrator_CancelRecurringPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderOrchestratorServer).CancelRecurringPayment(ctx, req.(*CancelRecurringPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderOrchestrator_ServiceDesc is the grpc.ServiceDesc for OrderOrchestrator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderOrchestrator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orchestrator.v1.OrderOrchestrator",
+	HandlerType: (*OrderOrchestratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitOrder",
+			Handler:    _OrderOrchestrator_SubmitOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderStatus",
+			Handler:    _OrderOrchestrator_GetOrderStatus_Handler,
+		},
+		{
+			MethodName: "ResolveManual",
+			Handler:    _OrderOrchestrator_ResolveManual_Handler,
+		},
+		{
+			MethodName: "CreateRecurringPayment",
+			Handler:    _OrderOrchestrator_CreateRecurringPayment_Handler,
+		},
+		{
+			MethodName: "CancelRecurringPayment",
+			Handler:    _OrderOrchestrator_CancelRecurringPayment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/orchestrator/v1/orchestrator.proto",
+}